@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/kelwang/gopherjs-mockup/storage"
+)
+
+const apiPrefix = "/api/mockups"
+
+var maxMockupSize int64 = 10 << 20
+
+// mockupAPI serves
+//
+//	GET    /api/mockups
+//	GET    /api/mockups/{id}
+//	PUT    /api/mockups/{id}
+//	DELETE /api/mockups/{id}
+//
+// Writes honor If-Match and If-None-Match so concurrent editors don't overwrite each other.
+type mockupAPI struct {
+	store storage.Store
+}
+
+func (api mockupAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, apiPrefix), "/")
+	if id == "" {
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, "GET")
+			return
+		}
+		api.list(w, r)
+		return
+	}
+	if !storage.ValidID(id) {
+		http.Error(w, storage.ErrInvalidID.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		api.get(w, r, id)
+	case http.MethodPut:
+		api.put(w, r, id)
+	case http.MethodDelete:
+		api.delete(w, r, id)
+	default:
+		methodNotAllowed(w, "GET, HEAD, PUT, DELETE")
+	}
+}
+
+func (api mockupAPI) list(w http.ResponseWriter, r *http.Request) {
+	list, err := api.store.List()
+	if err != nil {
+		storeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func (api mockupAPI) get(w http.ResponseWriter, r *http.Request, id string) {
+	m, err := api.store.Get(id)
+	if err != nil {
+		storeError(w, err)
+		return
+	}
+	w.Header().Set("ETag", m.ETag)
+	if storage.MatchETag(r.Header.Get("If-None-Match"), m.ETag, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(m.Data)
+}
+
+func (api mockupAPI) put(w http.ResponseWriter, r *http.Request, id string) {
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxMockupSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !json.Valid(data) {
+		http.Error(w, "mockup is not valid JSON", http.StatusBadRequest)
+		return
+	}
+
+	m, created, err := api.store.Put(id, data, condition(r))
+	if err != nil {
+		storeError(w, err)
+		return
+	}
+	w.Header().Set("ETag", m.ETag)
+	if created {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api mockupAPI) delete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := api.store.Delete(id, condition(r)); err != nil {
+		storeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func condition(r *http.Request) storage.Condition {
+	return storage.Condition{
+		IfMatch:     r.Header.Get("If-Match"),
+		IfNoneMatch: r.Header.Get("If-None-Match"),
+	}
+}
+
+func methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+func storeError(w http.ResponseWriter, err error) {
+	switch err {
+	case storage.ErrNotFound:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case storage.ErrPreconditionFailed:
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case storage.ErrInvalidID:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kelwang/gopherjs-mockup/storage"
+)
+
+const (
+	storedMockup = `{"version":1,"elements":[]}`
+	newMockup    = `{"version":1,"elements":[{}]}`
+)
+
+var storedTag = storage.ETag([]byte(storedMockup))
+
+// request serves a request with the headers in name, value pairs
+func request(api mockupAPI, method, path, body string, header ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, apiPrefix+path, strings.NewReader(body))
+	for k := 0; k+1 < len(header); k += 2 {
+		r.Header.Set(header[k], header[k+1])
+	}
+	w := httptest.NewRecorder()
+	api.ServeHTTP(w, r)
+	return w
+}
+
+func newTestAPI(t *testing.T) mockupAPI {
+	store := storage.NewMemoryStore()
+	if _, _, err := store.Put("stored", []byte(storedMockup), storage.Condition{}); err != nil {
+		t.Fatal(err)
+	}
+	return mockupAPI{store: store}
+}
+
+func TestMockupAPIGet(t *testing.T) {
+	api := newTestAPI(t)
+	w := request(api, "GET", "/stored", "")
+	if w.Code != http.StatusOK || w.Body.String() != storedMockup || w.Header().Get("ETag") != storedTag {
+		t.Errorf("get: %d %s with ETag %s", w.Code, w.Body, w.Header().Get("ETag"))
+	}
+	if w := request(api, "HEAD", "/stored", ""); w.Code != http.StatusOK || w.Header().Get("ETag") != storedTag {
+		t.Errorf("head: %d with ETag %s", w.Code, w.Header().Get("ETag"))
+	}
+	if w := request(api, "GET", "", ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"id":"stored"`) {
+		t.Errorf("list: %d %s", w.Code, w.Body)
+	}
+
+	// If-None-Match takes a list of tags and compares them weakly
+	for _, inm := range []string{storedTag, `"other", W/` + storedTag, "*"} {
+		if w := request(api, "GET", "/stored", "", "If-None-Match", inm); w.Code != http.StatusNotModified || w.Header().Get("ETag") != storedTag {
+			t.Errorf("get if none match %s: %d with ETag %s", inm, w.Code, w.Header().Get("ETag"))
+		}
+	}
+	if w := request(api, "GET", "/stored", "", "If-None-Match", `"other"`); w.Code != http.StatusOK || w.Body.String() != storedMockup {
+		t.Errorf("get if none match other: %d %s", w.Code, w.Body)
+	}
+}
+
+func TestMockupAPIWrite(t *testing.T) {
+	newTag := storage.ETag([]byte(newMockup))
+	api := newTestAPI(t)
+
+	w := request(api, "PUT", "/new", newMockup, "If-None-Match", "*")
+	if w.Code != http.StatusCreated || w.Header().Get("ETag") != newTag {
+		t.Errorf("create: %d with ETag %s", w.Code, w.Header().Get("ETag"))
+	}
+	if w := request(api, "PUT", "/new", storedMockup, "If-None-Match", "*"); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("create again: %d", w.Code)
+	}
+
+	// a stale tag doesn't overwrite the mockup, the current one does
+	if w := request(api, "PUT", "/stored", newMockup, "If-Match", `"other"`); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("replace stale: %d", w.Code)
+	}
+	w = request(api, "PUT", "/stored", newMockup, "If-Match", storedTag)
+	if w.Code != http.StatusNoContent || w.Header().Get("ETag") != newTag {
+		t.Errorf("replace: %d with ETag %s", w.Code, w.Header().Get("ETag"))
+	}
+	if w := request(api, "GET", "/stored", ""); w.Body.String() != newMockup {
+		t.Errorf("replaced with %s", w.Body)
+	}
+
+	if w := request(api, "DELETE", "/stored", "", "If-Match", storedTag); w.Code != http.StatusPreconditionFailed {
+		t.Errorf("delete stale: %d", w.Code)
+	}
+	if w := request(api, "DELETE", "/stored", ""); w.Code != http.StatusNoContent {
+		t.Errorf("delete: %d", w.Code)
+	}
+	if w := request(api, "GET", "/stored", ""); w.Code != http.StatusNotFound {
+		t.Errorf("get deleted: %d", w.Code)
+	}
+}
+
+func TestMockupAPIErrors(t *testing.T) {
+	api := newTestAPI(t)
+	cases := []struct {
+		method, path, body string
+		status             int
+		allow              string
+	}{
+		{"GET", "/missing", "", http.StatusNotFound, ""},
+		{"DELETE", "/missing", "", http.StatusNotFound, ""},
+		{"GET", "/a.b", "", http.StatusBadRequest, ""},
+		{"PUT", "/new", "{", http.StatusBadRequest, ""},
+		{"POST", "", "", http.StatusMethodNotAllowed, "GET"},
+		{"POST", "/stored", "", http.StatusMethodNotAllowed, "GET, HEAD, PUT, DELETE"},
+	}
+	for _, c := range cases {
+		w := request(api, c.method, c.path, c.body)
+		if w.Code != c.status || w.Header().Get("Allow") != c.allow {
+			t.Errorf("%s %s: %d allowing %q, want %d allowing %q", c.method, c.path, w.Code, w.Header().Get("Allow"), c.status, c.allow)
+		}
+	}
+}
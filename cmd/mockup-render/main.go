@@ -0,0 +1,72 @@
+// Command mockup-render renders a saved mockup to a standalone SVG and, optionally, a PNG.
+//
+//	mockup-render [-svg out.svg] [-png out.png] [-scale 2] [-margin 10] mockup.json
+//
+// Without -svg or -png the SVG is written to standard output.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/kelwang/gopherjs-mockup/mockup"
+)
+
+var (
+	svgPath = flag.String("svg", "", "write the SVG to this file")
+	pngPath = flag.String("png", "", "write a PNG to this file")
+	scale   = flag.Float64("scale", 1, "scale of the PNG")
+	margin  = flag.Float64("margin", 10, "space around the elements")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: mockup-render [flags] mockup.json")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || *scale <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0)); err != nil {
+		fmt.Fprintln(os.Stderr, "mockup-render:", err)
+		os.Exit(1)
+	}
+}
+
+func run(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	elements, err := mockup.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	doc := mockup.Render(elements, *margin)
+
+	if *svgPath == "" && *pngPath == "" {
+		_, err := fmt.Println(doc.String())
+		return err
+	}
+	if *svgPath != "" {
+		if err := ioutil.WriteFile(*svgPath, []byte(doc.String()), 0644); err != nil {
+			return err
+		}
+	}
+	if *pngPath != "" {
+		f, err := os.Create(*pngPath)
+		if err != nil {
+			return err
+		}
+		if err := writePNG(f, doc, *scale); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	return nil
+}
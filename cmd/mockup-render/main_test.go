@@ -0,0 +1,136 @@
+package main
+
+import (
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// renderFile runs the command on the mockup at scale 2 and returns the SVG and the PNG it wrote
+func renderFile(t *testing.T, mockup string) (string, image.Image, error) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "mockup.json")
+	if err := ioutil.WriteFile(in, []byte(mockup), 0644); err != nil {
+		t.Fatal(err)
+	}
+	*scale = 2
+	*svgPath, *pngPath = filepath.Join(dir, "out.svg"), filepath.Join(dir, "out.png")
+	if err := run(in); err != nil {
+		return "", nil, err
+	}
+
+	data, err := ioutil.ReadFile(*svgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(*pngPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), img, nil
+}
+
+func TestRunBox(t *testing.T) {
+	markup, img, err := renderFile(t, `{"version":1,"elements":[{"type":"box","id":"a","position":{"x":10,"y":10},"dimension":{"width":40,"height":20},"stroke":{"color":"black","thickness":1}}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the view box keeps the margin of 10 around the box
+	for _, s := range []string{`width="60" height="40" viewBox="0 0 60 40"`, `id="a"`} {
+		if !strings.Contains(markup, s) {
+			t.Errorf("%s not in\n%s", s, markup)
+		}
+	}
+	// the output is svg the package reads back as written
+	if doc, err := svg.ParseString(markup); err != nil || doc.String() != markup {
+		t.Errorf("read back\n%s, %v\nwant\n%s", doc, err, markup)
+	}
+
+	if b := img.Bounds(); b.Dx() != 120 || b.Dy() != 80 {
+		t.Fatalf("png of %dx%d, want 120x80", b.Dx(), b.Dy())
+	}
+	// the top edge of the box is stroked, its inside is left blank
+	if r, _, _, _ := img.At(60, 20).RGBA(); r == 0xffff {
+		t.Errorf("edge not painted, %v", img.At(60, 20))
+	}
+	if r, _, _, _ := img.At(60, 40).RGBA(); r != 0xffff {
+		t.Errorf("inside painted %v", img.At(60, 40))
+	}
+}
+
+func TestRunLabel(t *testing.T) {
+	markup, _, err := renderFile(t, `{"version":1,"elements":[{"type":"label","id":"l","position":{"x":0,"y":0},"dimension":{"width":40,"height":20},"text":{"content":"a<b","color":"black"},"stroke":{"color":"black","thickness":1}}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(markup, `>a&lt;b<`) {
+		t.Errorf("text not escaped in\n%s", markup)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if _, _, err := renderFile(t, `{"version":99,"elements":[]}`); err == nil || !strings.Contains(err.Error(), "unsupported format version") {
+		t.Errorf("newer version: %v", err)
+	}
+	if _, _, err := renderFile(t, `{"version":1,"elements":[{"type":"slider"}]}`); err == nil {
+		t.Error("unknown element rendered")
+	}
+	if err := run(filepath.Join(t.TempDir(), "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("missing file: %v", err)
+	}
+}
+
+func TestRunRotated(t *testing.T) {
+	markup, img, err := renderFile(t, `{"version":1,"elements":[{"type":"label","id":"l","position":{"x":10,"y":10},"dimension":{"width":40,"height":40},"rotation":45,"text":{"content":"Hi","color":"black"},"stroke":{"color":"black","thickness":1}}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the view box fits the corners of the turned square, 20√2 from its center
+	for _, s := range []string{`viewBox="-8.284271247461898 -8.284271247461902 76.5685424949238 76.5685424949238"`, `transform="translate(10 10) rotate(45 20 20)"`} {
+		if !strings.Contains(markup, s) {
+			t.Errorf("%s not in\n%s", s, markup)
+		}
+	}
+	if b := img.Bounds(); b.Dx() != 154 || b.Dy() != 154 {
+		t.Errorf("png of %dx%d, want 154x154", b.Dx(), b.Dy())
+	}
+}
+
+func TestRunWrapped(t *testing.T) {
+	markup, _, err := renderFile(t, `{"version":1,"elements":[{"type":"label","id":"l","position":{"x":0,"y":0},"dimension":{"width":60,"height":100},"text":{"content":"a label too long for one line","color":"black"},"stroke":{"color":"black","thickness":1}}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(markup, "<tspan"); n < 2 {
+		t.Errorf("%d lines in\n%s", n, markup)
+	}
+}
+
+func TestRunGroup(t *testing.T) {
+	markup, img, err := renderFile(t, `{"version":1,"elements":[{"type":"group","id":"g","position":{"x":0,"y":0},"dimension":{"width":30,"height":10},"stroke":{"color":"","thickness":0},"children":[
+		{"type":"box","id":"g_0","position":{"x":0,"y":0},"dimension":{"width":10,"height":10},"stroke":{"color":"black","thickness":1}},
+		{"type":"line","id":"g_1","position":{"x":20,"y":0},"dimension":{"width":10,"height":10},"stroke":{"color":"black","thickness":1}}]}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the group is framed by its children
+	for _, s := range []string{`viewBox="-10 -10 50 30"`, `id="g"`, `id="g_0"`, `id="g_1"`} {
+		if !strings.Contains(markup, s) {
+			t.Errorf("%s not in\n%s", s, markup)
+		}
+	}
+	if b := img.Bounds(); b.Dx() != 100 || b.Dy() != 60 {
+		t.Errorf("png of %dx%d, want 100x60", b.Dx(), b.Dy())
+	}
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// writePNG rasterizes the svg at the given scale
+func writePNG(w io.Writer, doc svg.Svg, scale float64) error {
+	c, err := newCanvas(doc, scale)
+	if err != nil {
+		return err
+	}
+	st := defaultStyle
+	for _, ele := range doc.Content {
+		c.draw(ele, st)
+	}
+	return png.Encode(w, c.img)
+}
+
+type point struct {
+	x float64
+	y float64
+}
+
+// style holds the presentation attributes inherited from the parent elements
+type style struct {
+	fill        string
+	fillOpacity float64
+	stroke      string
+	strokeWidth float64
+}
+
+var defaultStyle = style{
+	fill:        "black",
+	fillOpacity: 1,
+	stroke:      "none",
+	strokeWidth: 1,
+}
+
+// inherit applies the attributes an element sets, following what svg.Svg.String() writes out
+func (st style) inherit(fill string, opacity float64, s svg.Strokeable) style {
+	if fill != "" {
+		st.fill = fill
+	}
+	if opacity != 1 {
+		st.fillOpacity = opacity
+	}
+	if s.Stroke != "" {
+		st.stroke = s.Stroke
+	}
+	if s.StrokeWidth != 0 {
+		st.strokeWidth = s.StrokeWidth
+	}
+	return st
+}
+
+type canvas struct {
+	img   *image.RGBA
+	mask  *image.Alpha
+	z     *vector.Rasterizer
+	fonts [4]*sfnt.Font
+	buf   sfnt.Buffer
+	scale float64
+	ox    float64
+	oy    float64
+	// m maps the coordinates of the element being drawn to the root's
+	m svg.Matrix
+}
+
+func newCanvas(doc svg.Svg, scale float64) (*canvas, error) {
+	vb := doc.ViewBox
+	if vb == nil {
+		vb = &svg.ViewBox{Width: doc.Width, Height: doc.Height}
+	}
+	w := int(math.Ceil(vb.Width * scale))
+	h := int(math.Ceil(vb.Height * scale))
+	fonts := [4]*sfnt.Font{}
+	for k, ttf := range goFonts {
+		f, err := sfnt.Parse(ttf)
+		if err != nil {
+			return nil, err
+		}
+		fonts[k] = f
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	return &canvas{
+		img:   img,
+		mask:  image.NewAlpha(img.Bounds()),
+		z:     vector.NewRasterizer(w, h),
+		fonts: fonts,
+		scale: scale,
+		ox:    vb.X,
+		oy:    vb.Y,
+		m:     svg.Identity,
+	}, nil
+}
+
+// goFonts are the fonts every family is drawn with: regular, bold, italic and bold italic
+var goFonts = [4][]byte{goregular.TTF, gobold.TTF, goitalic.TTF, gobolditalic.TTF}
+
+// outlines returns the Go font drawing the font, whatever its family
+func (c *canvas) outlines(f svg.Font) *sfnt.Font {
+	k := 0
+	if f.Bold {
+		k++
+	}
+	if f.Italic {
+		k += 2
+	}
+	return c.fonts[k]
+}
+
+func (c *canvas) device(p point) (float32, float32) {
+	q := c.m.Apply(svg.NewPoint(p.x, p.y))
+	return float32((q.X() - c.ox) * c.scale), float32((q.Y() - c.oy) * c.scale)
+}
+
+// transformed is an element with a transform attribute
+type transformed interface {
+	Matrix() svg.Matrix
+}
+
+func (c *canvas) draw(ele svg.SvgElement, st style) {
+	if t, ok := ele.(transformed); ok {
+		m := c.m
+		c.m = m.Mul(t.Matrix())
+		defer func() { c.m = m }()
+	}
+	switch se := ele.(type) {
+	case *svg.Group:
+		st = st.inherit(se.Fillable.Fill, se.Fillable.Opacity, se.Strokeable)
+		for _, v := range se.Content {
+			c.draw(v, st)
+		}
+	case *svg.Rect:
+		st = st.inherit(se.Fillable.Fill, se.Fillable.Opacity, se.Strokeable)
+		c.shape([][]point{rectPoints(se.X, se.Y, se.Width, se.Height, se.RX, se.RY)}, true, st)
+	case *svg.Line:
+		st = st.inherit("", 1, se.Strokeable)
+		c.shape([][]point{{{se.X1, se.Y1}, {se.X2, se.Y2}}}, false, st)
+	case *svg.Circle:
+		st = st.inherit(se.Fillable.Fill, se.Fillable.Opacity, se.Strokeable)
+		c.shape([][]point{ellipsePoints(se.X, se.Y, se.R, se.R)}, true, st)
+	case *svg.Ellipse:
+		st = st.inherit(se.Fillable.Fill, se.Fillable.Opacity, se.Strokeable)
+		c.shape([][]point{ellipsePoints(se.X, se.Y, se.RX, se.RY)}, true, st)
+	case *svg.Polygon:
+		st = st.inherit(se.Fillable.Fill, se.Fillable.Opacity, se.Strokeable)
+		c.shape([][]point{polyPoints(se.Points)}, true, st)
+	case *svg.Polyline:
+		st = st.inherit(se.Fillable.Fill, se.Fillable.Opacity, se.Strokeable)
+		c.shape([][]point{polyPoints(se.Points)}, false, st)
+	case *svg.Path:
+		st = st.inherit(se.Fillable.Fill, se.Fillable.Opacity, se.Strokeable)
+		c.shape(pathPoints(se.D), false, st)
+	case *svg.Text:
+		st = st.inherit(se.Fillable.Fill, se.Fillable.Opacity, se.Strokeable)
+		c.text(se, st)
+	}
+}
+
+// shape fills and strokes the polygons
+func (c *canvas) shape(polys [][]point, closed bool, st style) {
+	if col, ok := parseColor(st.fill, st.fillOpacity); ok {
+		c.clearMask()
+		for _, poly := range polys {
+			c.polygon(poly)
+		}
+		c.paint(col)
+	}
+	if col, ok := parseColor(st.stroke, 1); ok && st.strokeWidth > 0 {
+		c.clearMask()
+		for _, poly := range polys {
+			c.polyline(poly, closed, st.strokeWidth/2)
+		}
+		c.paint(col)
+	}
+}
+
+// text is drawn from the outlines of its glyphs, through the transform like the shapes
+func (c *canvas) text(se *svg.Text, st style) {
+	// text is drawn in its fill color, or its stroke color when the fill is hidden
+	col, ok := parseColor(st.fill, st.fillOpacity)
+	if !ok {
+		if col, ok = parseColor(st.stroke, 1); !ok {
+			return
+		}
+	}
+	f := se.Font()
+	l := textLine{outlines: c.outlines(f), font: f, anchor: se.TextAnchor, underline: se.Underline, col: col}
+	c.line(l, se.Content, se.X, se.Y)
+	for _, span := range se.Spans {
+		c.line(l, span.Content, span.X, span.Y)
+	}
+}
+
+// textLine is how the lines of a text are drawn
+type textLine struct {
+	outlines  *sfnt.Font
+	font      svg.Font
+	anchor    svg.TextAnchor
+	underline bool
+	col       color.Color
+}
+
+// line draws a line of text anchored at x, y
+func (c *canvas) line(l textLine, s string, x, y float64) {
+	if s == "" {
+		return
+	}
+	m := svg.MeasureText(s, l.font)
+	x0 := x
+	switch l.anchor {
+	case svg.MIDDLE:
+		x0 -= m.Width / 2
+	case svg.END:
+		x0 -= m.Width
+	}
+	// the glyphs are loaded in font units, k scales them to the font size
+	upem := fixed.I(int(l.outlines.UnitsPerEm()))
+	k := l.font.Size / float64(l.outlines.UnitsPerEm())
+	b := c.mask.Bounds()
+	c.clearMask()
+	c.z.Reset(b.Dx(), b.Dy())
+	c.z.DrawOp = draw.Over
+	pen := x0
+	for _, r := range s {
+		g, err := l.outlines.GlyphIndex(&c.buf, r)
+		if err != nil {
+			continue
+		}
+		if segments, err := l.outlines.LoadGlyph(&c.buf, g, upem, nil); err == nil {
+			c.glyph(segments, pen, y, k)
+		}
+		if a, err := l.outlines.GlyphAdvance(&c.buf, g, upem, font.HintingNone); err == nil {
+			pen += float64(a) / 64 * k
+		}
+	}
+	c.z.Draw(c.mask, b, image.Opaque, image.Point{})
+	c.paint(l.col)
+
+	if l.underline {
+		top, thickness := y+l.font.Size/10, l.font.Size/16
+		c.clearMask()
+		c.polygon([]point{{x0, top}, {x0 + m.Width, top}, {x0 + m.Width, top + thickness}, {x0, top + thickness}})
+		c.paint(l.col)
+	}
+}
+
+// glyph adds the outline of a glyph to the path of the rasterizer, its origin at x, y and its font units scaled by k
+func (c *canvas) glyph(segments sfnt.Segments, x, y, k float64) {
+	at := func(p fixed.Point26_6) (float32, float32) {
+		return c.device(point{x + float64(p.X)/64*k, y + float64(p.Y)/64*k})
+	}
+	for _, seg := range segments {
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			c.z.ClosePath()
+			c.z.MoveTo(at(seg.Args[0]))
+		case sfnt.SegmentOpLineTo:
+			c.z.LineTo(at(seg.Args[0]))
+		case sfnt.SegmentOpQuadTo:
+			ax, ay := at(seg.Args[0])
+			bx, by := at(seg.Args[1])
+			c.z.QuadTo(ax, ay, bx, by)
+		case sfnt.SegmentOpCubeTo:
+			ax, ay := at(seg.Args[0])
+			bx, by := at(seg.Args[1])
+			cx, cy := at(seg.Args[2])
+			c.z.CubeTo(ax, ay, bx, by, cx, cy)
+		}
+	}
+	c.z.ClosePath()
+}
+
+func (c *canvas) clearMask() {
+	draw.Draw(c.mask, c.mask.Bounds(), image.Transparent, image.Point{}, draw.Src)
+}
+
+// paint draws the color through the mask
+func (c *canvas) paint(col color.Color) {
+	draw.DrawMask(c.img, c.img.Bounds(), image.NewUniform(col), image.Point{}, c.mask, image.Point{}, draw.Over)
+}
+
+// polygon adds the polygon to the mask
+func (c *canvas) polygon(poly []point) {
+	if len(poly) < 3 {
+		return
+	}
+	b := c.mask.Bounds()
+	c.z.Reset(b.Dx(), b.Dy())
+	c.z.DrawOp = draw.Over
+	c.z.MoveTo(c.device(poly[0]))
+	for _, p := range poly[1:] {
+		c.z.LineTo(c.device(p))
+	}
+	c.z.ClosePath()
+	c.z.Draw(c.mask, b, image.Opaque, image.Point{})
+}
+
+// polyline adds the outline of the polyline, r being half of the stroke width, to the mask
+func (c *canvas) polyline(poly []point, closed bool, r float64) {
+	if closed && len(poly) > 2 {
+		poly = append(poly, poly[0])
+	}
+	for k := 1; k < len(poly); k++ {
+		a, b := poly[k-1], poly[k]
+		dx, dy := b.x-a.x, b.y-a.y
+		l := math.Hypot(dx, dy)
+		if l == 0 {
+			continue
+		}
+		nx, ny := -dy/l*r, dx/l*r
+		c.polygon([]point{{a.x + nx, a.y + ny}, {b.x + nx, b.y + ny}, {b.x - nx, b.y - ny}, {a.x - nx, a.y - ny}})
+	}
+	// round joins
+	for k, p := range poly {
+		if k == 0 && !closed || k == len(poly)-1 && !closed {
+			continue
+		}
+		c.polygon(ellipsePoints(p.x, p.y, r, r))
+	}
+}
+
+func rectPoints(x, y, w, h, rx, ry float64) []point {
+	if rx == 0 {
+		rx = ry
+	}
+	if ry == 0 {
+		ry = rx
+	}
+	rx = math.Min(rx, w/2)
+	ry = math.Min(ry, h/2)
+	if rx <= 0 || ry <= 0 {
+		return []point{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}
+	}
+	pts := []point{}
+	corners := []struct{ cx, cy, start float64 }{
+		{x + w - rx, y + ry, -math.Pi / 2},
+		{x + w - rx, y + h - ry, 0},
+		{x + rx, y + h - ry, math.Pi / 2},
+		{x + rx, y + ry, math.Pi},
+	}
+	for _, corner := range corners {
+		for k := 0; k <= 8; k++ {
+			a := corner.start + float64(k)*math.Pi/16
+			pts = append(pts, point{corner.cx + rx*math.Cos(a), corner.cy + ry*math.Sin(a)})
+		}
+	}
+	return pts
+}
+
+func ellipsePoints(cx, cy, rx, ry float64) []point {
+	n := 64
+	pts := make([]point, n)
+	for k := range pts {
+		a := 2 * math.Pi * float64(k) / float64(n)
+		pts[k] = point{cx + rx*math.Cos(a), cy + ry*math.Sin(a)}
+	}
+	return pts
+}
+
+func polyPoints(ps svg.Points) []point {
+	poly := make([]point, len(ps))
+	for k, p := range ps {
+		poly[k] = point{p.X(), p.Y()}
+	}
+	return poly
+}
+
+func pathPoints(d svg.PathItems) [][]point {
+	polys := [][]point{}
+	for _, ps := range d.Flatten() {
+		polys = append(polys, polyPoints(ps))
+	}
+	return polys
+}
+
+var namedColors = map[string]color.RGBA{
+	"black":     {0, 0, 0, 255},
+	"white":     {255, 255, 255, 255},
+	"red":       {255, 0, 0, 255},
+	"green":     {0, 128, 0, 255},
+	"blue":      {0, 0, 255, 255},
+	"yellow":    {255, 255, 0, 255},
+	"orange":    {255, 165, 0, 255},
+	"grey":      {128, 128, 128, 255},
+	"gray":      {128, 128, 128, 255},
+	"lightgrey": {211, 211, 211, 255},
+	"lightgray": {211, 211, 211, 255},
+	"darkgrey":  {169, 169, 169, 255},
+	"darkgray":  {169, 169, 169, 255},
+}
+
+// parseColor understands named colors, #rgb, #rrggbb and rgb(r, g, b).
+// It reports false for "none", invisible and unknown colors.
+func parseColor(s string, opacity float64) (color.Color, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if opacity <= 0 {
+		return nil, false
+	}
+	c, ok := namedColors[s]
+	switch {
+	case ok:
+	case strings.HasPrefix(s, "#") && (len(s) == 4 || len(s) == 7):
+		hex := s[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return nil, false
+		}
+		c = color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 255}
+	case strings.HasPrefix(s, "rgb(") && strings.HasSuffix(s, ")"):
+		parts := strings.Split(s[4:len(s)-1], ",")
+		if len(parts) != 3 {
+			return nil, false
+		}
+		rgb := [3]uint8{}
+		for k, p := range parts {
+			v, err := strconv.Atoi(strings.TrimSpace(p))
+			if err != nil || v < 0 || v > 255 {
+				return nil, false
+			}
+			rgb[k] = uint8(v)
+		}
+		c = color.RGBA{rgb[0], rgb[1], rgb[2], 255}
+	default:
+		return nil, false
+	}
+	a := math.Min(opacity, 1)
+	return color.NRGBA{c.R, c.G, c.B, uint8(a*255 + 0.5)}, true
+}
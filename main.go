@@ -1,23 +1,36 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
 	"net/http"
 
 	gbuild "github.com/gopherjs/gopherjs/build"
+	"github.com/kelwang/gopherjs-mockup/storage"
 	"github.com/kelwang/gs/tool"
 )
 
 var basePath = "github.com/kelwang/gopherjs-mockup/"
 
+var dataDir = flag.String("data", "mockups", "directory where mockups are saved")
+
 // Default Request Handler
 func defaultHandler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, `<html><head><title>GopherJS Mockup</title></head><body><script type="text/javascript" src="//cdnjs.cloudflare.com/ajax/libs/jquery/2.2.4/jquery.min.js"></script><script src="/mockup/script/script.js"></script></body></html>`)
 }
 
 func main() {
+	flag.Parse()
+	store, err := storage.NewFileStore(*dataDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	options := &gbuild.Options{CreateMapFile: true}
 	http.Handle("/mockup/", tool.Handler(basePath+"mockup/", options, len("/mockup/")))
+	http.Handle(apiPrefix, mockupAPI{store: store})
+	http.Handle(apiPrefix+"/", mockupAPI{store: store})
 	http.HandleFunc("/", defaultHandler)
 	http.ListenAndServe(":9390", nil)
 }
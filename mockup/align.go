@@ -0,0 +1,189 @@
+//go:build js
+
+package mockup
+
+import (
+	"sort"
+
+	"github.com/gopherjs/jquery"
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// AlignLeft lines up the left edges of the selected elements with the left of the selection
+func (ed *ControlEditable) AlignLeft() {
+	ed.alignTo(func(f, b svg.BBox) (float64, float64) { return f.X - b.X, 0 })
+}
+
+// AlignCenter lines up the centers of the selected elements on a vertical line through the center of the selection
+func (ed *ControlEditable) AlignCenter() {
+	ed.alignTo(func(f, b svg.BBox) (float64, float64) { return f.Center().X() - b.Center().X(), 0 })
+}
+
+// AlignRight lines up the right edges of the selected elements with the right of the selection
+func (ed *ControlEditable) AlignRight() {
+	ed.alignTo(func(f, b svg.BBox) (float64, float64) { return f.Right() - b.Right(), 0 })
+}
+
+// AlignTop lines up the top edges of the selected elements with the top of the selection
+func (ed *ControlEditable) AlignTop() {
+	ed.alignTo(func(f, b svg.BBox) (float64, float64) { return 0, f.Y - b.Y })
+}
+
+// AlignMiddle lines up the centers of the selected elements on a horizontal line through the center of the selection
+func (ed *ControlEditable) AlignMiddle() {
+	ed.alignTo(func(f, b svg.BBox) (float64, float64) { return 0, f.Center().Y() - b.Center().Y() })
+}
+
+// AlignBottom lines up the bottom edges of the selected elements with the bottom of the selection
+func (ed *ControlEditable) AlignBottom() {
+	ed.alignTo(func(f, b svg.BBox) (float64, float64) { return 0, f.Bottom() - b.Bottom() })
+}
+
+// DistributeHorizontally spaces the selected elements evenly from left to right,
+// the leftmost and the rightmost stay in place
+func (ed *ControlEditable) DistributeHorizontally() {
+	ed.arrange(3, func(elements []MockupElement) { distribute(elements, false) })
+}
+
+// DistributeVertically spaces the selected elements evenly from top to bottom,
+// the topmost and the bottommost stay in place
+func (ed *ControlEditable) DistributeVertically() {
+	ed.arrange(3, func(elements []MockupElement) { distribute(elements, true) })
+}
+
+// alignTo moves every selected element by the offset from its box to the frame of the selection
+func (ed *ControlEditable) alignTo(offset func(frame, b svg.BBox) (float64, float64)) {
+	ed.arrange(2, func(elements []MockupElement) {
+		frame := boxOf(elements[0])
+		for _, e := range elements[1:] {
+			frame = frame.Union(boxOf(e))
+		}
+		for _, e := range elements {
+			dx, dy := offset(frame, boxOf(e))
+			shiftBy(e, dx, dy)
+		}
+	})
+}
+
+// arrange moves the selected elements, when there are at least n of them, and records it as a single history entry
+func (ed *ControlEditable) arrange(n int, move func(elements []MockupElement)) {
+	selected := ed.doc.Selected()
+	if len(selected) < n {
+		return
+	}
+	g := newGesture(selected)
+	move(selected)
+	if box, ok := ed.doc.SelectionBox(); ok {
+		box.layout()
+	}
+	ed.record(g)
+}
+
+// distribute moves the elements so that the gaps between them are equal, along the vertical axis when vertical is set
+func distribute(elements []MockupElement, vertical bool) {
+	boxes := make([]svg.BBox, len(elements))
+	order := make([]int, len(elements))
+	space := 0.0
+	for k, e := range elements {
+		boxes[k] = boxOf(e)
+		if vertical {
+			boxes[k] = flip(boxes[k])
+		}
+		order[k] = k
+		space -= boxes[k].Width
+	}
+	sort.SliceStable(order, func(i, j int) bool { return boxes[order[i]].X < boxes[order[j]].X })
+	first, last := boxes[order[0]], boxes[order[len(order)-1]]
+	space += last.Right() - first.X
+	gap := space / float64(len(elements)-1)
+	x := first.X
+	for _, k := range order {
+		if vertical {
+			shiftBy(elements[k], 0, x-boxes[k].X)
+		} else {
+			shiftBy(elements[k], x-boxes[k].X, 0)
+		}
+		x += boxes[k].Width + gap
+	}
+}
+
+// boxOf is the frame of the element as MoveTo places it, without its rotation
+func boxOf(ele MockupElement) svg.BBox {
+	w, h, x, y := ele.GetWHXY()
+	return svg.NewBBox(x, y, x+w, y+h)
+}
+
+// alignAction is a button of the AlignToolbar, with its default shortcut
+type alignAction struct {
+	label    string
+	title    string
+	min      int
+	shortcut Shortcut
+	do       Action
+}
+
+var alignActions = []alignAction{
+	{label: "Left", title: "Align left", min: 2, shortcut: Shortcut{Key: 'A', Alt: true}, do: (*ControlEditable).AlignLeft},
+	{label: "Center", title: "Align center", min: 2, shortcut: Shortcut{Key: 'H', Alt: true}, do: (*ControlEditable).AlignCenter},
+	{label: "Right", title: "Align right", min: 2, shortcut: Shortcut{Key: 'D', Alt: true}, do: (*ControlEditable).AlignRight},
+	{label: "Top", title: "Align top", min: 2, shortcut: Shortcut{Key: 'W', Alt: true}, do: (*ControlEditable).AlignTop},
+	{label: "Middle", title: "Align middle", min: 2, shortcut: Shortcut{Key: 'V', Alt: true}, do: (*ControlEditable).AlignMiddle},
+	{label: "Bottom", title: "Align bottom", min: 2, shortcut: Shortcut{Key: 'S', Alt: true}, do: (*ControlEditable).AlignBottom},
+	{label: "Distribute horizontally", title: "Distribute horizontally", min: 3, shortcut: Shortcut{Key: 'H', Shift: true, Alt: true}, do: (*ControlEditable).DistributeHorizontally},
+	{label: "Distribute vertically", title: "Distribute vertically", min: 3, shortcut: Shortcut{Key: 'V', Shift: true, Alt: true}, do: (*ControlEditable).DistributeVertically},
+}
+
+// AlignToolbar is the row of buttons aligning and distributing the selected elements,
+// a button is disabled until enough elements are selected
+type AlignToolbar struct {
+	X       float64
+	Y       float64
+	Width   float64
+	ed      *ControlEditable
+	bar     jquery.JQuery
+	buttons []jquery.JQuery
+}
+
+func NewAlignToolbar(x, y, width float64) *AlignToolbar {
+	return &AlignToolbar{
+		X:     x,
+		Y:     y,
+		Width: width,
+	}
+}
+
+// BindEvents adds the toolbar to the page, its buttons act on the selection of the document edited by ed
+func (at *AlignToolbar) BindEvents(ed *ControlEditable) {
+	at.ed = ed
+	at.bar = jQuery("<div>").SetCss(map[string]interface{}{
+		"position": "absolute",
+		"left":     px(at.X),
+		"top":      px(at.Y),
+		"width":    px(at.Width),
+		"font":     "12px sans-serif",
+	})
+	for _, a := range alignActions {
+		a := a
+		b := jQuery("<button>").SetText(a.label).SetAttr("title", a.title+" ("+a.shortcut.String()+")")
+		// the selection stays when the button is pressed
+		b.On(jquery.MOUSEDOWN, func(e jquery.Event) { e.PreventDefault() })
+		b.On(jquery.CLICK, func(e jquery.Event) {
+			a.do(at.ed)
+			at.Refresh()
+		})
+		at.bar.Append(b)
+		at.buttons = append(at.buttons, b)
+	}
+	at.bar.AppendTo("body")
+
+	ed.doc.OnChange(func(c Change) { at.Refresh() })
+	at.Refresh()
+}
+
+// Refresh enables the buttons of the actions the selection is large enough for
+func (at *AlignToolbar) Refresh() {
+	n := len(at.ed.doc.Selected())
+	for k, a := range alignActions {
+		at.buttons[k].SetProp("disabled", n < a.min)
+	}
+}
@@ -1,6 +1,144 @@
+//go:build js
+
 package mockup
 
-import ()
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/gopherjs/jquery"
+)
 
+// AttributeEditor is the side panel showing the attributes of the selected element.
+// An edit is applied to the element, as one step of the history, once it is committed
+// with Enter or by leaving the field.
 type AttributeEditor struct {
+	X      float64
+	Y      float64
+	Width  float64
+	ed     *ControlEditable
+	panel  jquery.JQuery
+	fields []*attributeField
+	// focused is the field being edited
+	focused *attributeField
+}
+
+type attributeField struct {
+	attribute
+	row   jquery.JQuery
+	input jquery.JQuery
+}
+
+func NewAttributeEditor(x, y, width float64) *AttributeEditor {
+	return &AttributeEditor{
+		X:     x,
+		Y:     y,
+		Width: width,
+	}
+}
+
+// BindEvents adds the panel to the page, it follows the selection of the document edited by ed
+func (ae *AttributeEditor) BindEvents(ed *ControlEditable) {
+	ae.ed = ed
+	ae.panel = jQuery("<div>").SetCss(map[string]interface{}{
+		"position":    "absolute",
+		"left":        px(ae.X),
+		"top":         px(ae.Y),
+		"width":       px(ae.Width),
+		"font":        "12px sans-serif",
+		"color":       DARKGREY,
+		"border":      "1px solid " + DARKGREY,
+		"padding":     px(textPadding),
+		"box-sizing":  "border-box",
+		"line-height": "1.6",
+	})
+	for _, a := range attributes {
+		f := &attributeField{attribute: a, input: a.newInput()}
+		f.input.SetCss(map[string]interface{}{"display": "block", "width": "100%", "box-sizing": "border-box"})
+		f.row = jQuery("<label>").SetCss("display", "block").Append(jQuery("<span>").SetText(a.name), f.input)
+		f.input.On(jquery.FOCUS, func(e jquery.Event) { ae.focused = f })
+		f.input.On(jquery.CHANGE, func(e jquery.Event) { ae.apply(f) })
+		f.input.On(jquery.BLUR, func(e jquery.Event) { ae.blur() })
+		ae.panel.Append(f.row)
+		ae.fields = append(ae.fields, f)
+	}
+	// typing in the panel is not a shortcut of the page
+	ae.panel.On(jquery.KEYDOWN, func(e jquery.Event) { e.StopPropagation() })
+	ae.panel.AppendTo("body")
+
+	ed.doc.OnChange(func(c Change) { ae.Refresh() })
+	// gestures change the geometry without changing the document
+	jQuery(document).On(jquery.MOUSEUP, func(e jquery.Event) { ae.Refresh() })
+	jQuery(document).On(jquery.KEYUP, func(e jquery.Event) { ae.Refresh() })
+	ae.Refresh()
+}
+
+func (a attribute) newInput() jquery.JQuery {
+	switch a.kind {
+	case readonlyInput:
+		return jQuery("<input>").SetAttr("readonly", "readonly")
+	case numberInput:
+		return jQuery("<input>").SetAttr("type", "number").SetAttr("step", "any")
+	case textareaInput:
+		return jQuery("<textarea>").SetAttr("rows", 3)
+	case selectInput:
+		s := jQuery("<select>")
+		for t := None; t <= VeryThick; t++ {
+			s.Append(jQuery("<option>").SetVal(strconv.Itoa(int(t))).SetText(t.String()))
+		}
+		return s
+	}
+	return jQuery("<input>").SetAttr("type", "text")
+}
+
+// selection is the record of the element shown by the panel, the only one selected
+func (ae *AttributeEditor) selection() (elementRecord, bool) {
+	selected := ae.ed.doc.Selected()
+	if len(selected) != 1 {
+		return elementRecord{}, false
+	}
+	rec, err := newElementRecord(selected[0])
+	return rec, err == nil
+}
+
+// Refresh shows the attributes of the selected element, the panel is hidden without one.
+// The field being edited keeps what is typed in it.
+func (ae *AttributeEditor) Refresh() {
+	rec, ok := ae.selection()
+	if !ok {
+		ae.panel.Hide()
+		return
+	}
+	ae.panel.Show()
+	for _, f := range ae.fields {
+		v, ok := f.get(&rec)
+		if !ok {
+			f.row.Hide()
+			continue
+		}
+		f.row.Show()
+		if f != ae.focused {
+			f.input.SetVal(v)
+		}
+	}
+}
+
+// apply sets the attribute of the element to the value of the field and records it in the history
+func (ae *AttributeEditor) apply(f *attributeField) {
+	before, ok := ae.selection()
+	if !ok || f.set == nil {
+		return
+	}
+	// a record of its own, set changes the text and fill it points to
+	after, _ := ae.selection()
+	if !f.set(&after, f.input.Val()) || reflect.DeepEqual(before, after) {
+		return
+	}
+	ae.ed.History.Do(newRecordCommand(ae.ed.doc, before, after))
+}
+
+func (ae *AttributeEditor) blur() {
+	ae.focused = nil
+	// an invalid value is replaced by the attribute of the element
+	ae.Refresh()
 }
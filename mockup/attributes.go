@@ -0,0 +1,158 @@
+package mockup
+
+import (
+	"strconv"
+	"strings"
+)
+
+// attribute is a field of the panel, it reads and writes the record of the element.
+// get reports false when the element has no such attribute, set false when the value is invalid.
+type attribute struct {
+	name string
+	kind string
+	get  func(rec *elementRecord) (string, bool)
+	set  func(rec *elementRecord, v string) bool
+}
+
+// input kinds of the attributes
+const (
+	readonlyInput = "readonly"
+	textInput     = "text"
+	numberInput   = "number"
+	textareaInput = "textarea"
+	selectInput   = "select"
+)
+
+var attributes = []attribute{
+	{
+		name: "id",
+		kind: readonlyInput,
+		get:  func(rec *elementRecord) (string, bool) { return rec.ID, true },
+	},
+	{
+		name: "x",
+		kind: numberInput,
+		get:  func(rec *elementRecord) (string, bool) { return formatFloat(rec.Position.X), true },
+		set:  func(rec *elementRecord, v string) bool { return parseAttr(v, &rec.Position.X, false) },
+	},
+	{
+		name: "y",
+		kind: numberInput,
+		get:  func(rec *elementRecord) (string, bool) { return formatFloat(rec.Position.Y), true },
+		set:  func(rec *elementRecord, v string) bool { return parseAttr(v, &rec.Position.Y, false) },
+	},
+	{
+		name: "width",
+		kind: numberInput,
+		get:  func(rec *elementRecord) (string, bool) { return formatFloat(rec.Dimension.Width), true },
+		// a line goes left of its start with a negative width
+		set: func(rec *elementRecord, v string) bool {
+			return parseAttr(v, &rec.Dimension.Width, rec.Type != LineType)
+		},
+	},
+	{
+		name: "height",
+		kind: numberInput,
+		get:  func(rec *elementRecord) (string, bool) { return formatFloat(rec.Dimension.Height), true },
+		set: func(rec *elementRecord, v string) bool {
+			return parseAttr(v, &rec.Dimension.Height, rec.Type != LineType)
+		},
+	},
+	{
+		name: "text",
+		kind: textareaInput,
+		get: func(rec *elementRecord) (string, bool) {
+			if rec.Text == nil {
+				return "", false
+			}
+			return rec.Text.Content, true
+		},
+		set: func(rec *elementRecord, v string) bool {
+			rec.Text.Content = v
+			return true
+		},
+	},
+	{
+		name: "text color",
+		kind: textInput,
+		get: func(rec *elementRecord) (string, bool) {
+			if rec.Text == nil {
+				return "", false
+			}
+			return rec.Text.Color, true
+		},
+		set: func(rec *elementRecord, v string) bool {
+			rec.Text.Color = strings.TrimSpace(v)
+			return true
+		},
+	},
+	{
+		name: "stroke color",
+		kind: textInput,
+		get:  func(rec *elementRecord) (string, bool) { return rec.Stroke.Color, true },
+		set: func(rec *elementRecord, v string) bool {
+			rec.Stroke.Color = strings.TrimSpace(v)
+			return true
+		},
+	},
+	{
+		name: "thickness",
+		kind: selectInput,
+		get:  func(rec *elementRecord) (string, bool) { return strconv.Itoa(int(rec.Stroke.Thickness)), true },
+		set: func(rec *elementRecord, v string) bool {
+			t, err := strconv.Atoi(v)
+			if err != nil || t < int(None) || t > int(VeryThick) {
+				return false
+			}
+			rec.Stroke.Thickness = Thickness(t)
+			return true
+		},
+	},
+	{
+		name: "fill color",
+		kind: textInput,
+		get: func(rec *elementRecord) (string, bool) {
+			if rec.Fill == nil {
+				return "", false
+			}
+			return rec.Fill.Color, true
+		},
+		set: func(rec *elementRecord, v string) bool {
+			rec.Fill.Color = strings.TrimSpace(v)
+			return true
+		},
+	},
+	{
+		name: "fill opacity",
+		kind: numberInput,
+		get: func(rec *elementRecord) (string, bool) {
+			if rec.Fill == nil {
+				return "", false
+			}
+			return formatFloat(rec.Fill.Opacity), true
+		},
+		set: func(rec *elementRecord, v string) bool {
+			o := rec.Fill.Opacity
+			if !parseAttr(v, &o, true) || o > 1 {
+				return false
+			}
+			rec.Fill.Opacity = o
+			return true
+		},
+	},
+}
+
+// parseAttr reads a number into f, it reports false and leaves f alone when v is not a number,
+// or is negative while positive is set
+func parseAttr(v string, f *float64, positive bool) bool {
+	n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || (positive && n < 0) {
+		return false
+	}
+	*f = n
+	return true
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
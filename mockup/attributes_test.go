@@ -0,0 +1,67 @@
+package mockup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// setAttributes edits the element with the attributes of the panel, the way the panel does
+func setAttributes(doc *Document, h *History, id string, values map[string]string) bool {
+	ele, _ := doc.Get(id)
+	before, err := newElementRecord(ele)
+	if err != nil {
+		return false
+	}
+	after, _ := newElementRecord(ele)
+	for _, a := range attributes {
+		if v, ok := values[a.name]; ok && (a.set == nil || !a.set(&after, v)) {
+			return false
+		}
+	}
+	h.Do(newRecordCommand(doc, before, after))
+	return true
+}
+
+func TestPanelFill(t *testing.T) {
+	e := svg.DRAGGABLE | svg.EDITABLE
+	for _, ele := range []MockupElement{
+		NewTextBox(80, 20, 0, 0, "text", "t", e),
+		NewButton(80, 20, 0, 0, "OK", "b", e),
+		NewLabel(80, 20, 0, 0, "label", "l", e),
+		NewBox(80, 20, 0, 0, "x", e),
+	} {
+		doc, h := NewDocument(ele), NewHistory()
+		if !setAttributes(doc, h, ele.Id(), map[string]string{"fill color": "red", "fill opacity": "1"}) {
+			t.Fatalf("%s: fill not set", ele.Id())
+		}
+		got, _ := doc.Get(ele.Id())
+		markup := got.Svg().String()
+		if !strings.Contains(markup, `fill="red" stroke`) && !strings.Contains(markup, `fill="red" >`) {
+			t.Errorf("%s: opaque red fill not in %s", ele.Id(), markup)
+		}
+		// the fill-opacity of a group would be inherited by the shape
+		if tag := markup[:strings.Index(markup, ">")]; strings.HasPrefix(tag, "<g") && strings.Contains(tag, "fill-opacity") {
+			t.Errorf("%s: group sets the opacity in %s", ele.Id(), markup)
+		}
+
+		h.Undo()
+		got, _ = doc.Get(ele.Id())
+		if strings.Contains(got.Svg().String(), `fill="red"`) {
+			t.Errorf("%s: fill not undone", ele.Id())
+		}
+	}
+}
+
+func TestPanelInvalidValues(t *testing.T) {
+	doc, h := NewDocument(NewBox(80, 20, 0, 0, "x", 0)), NewHistory()
+	for name, v := range map[string]string{"width": "-1", "fill opacity": "2", "thickness": "9", "x": "left", "id": "y"} {
+		if setAttributes(doc, h, "x", map[string]string{name: v}) {
+			t.Errorf("%s set to %q", name, v)
+		}
+	}
+	if h.CanUndo() {
+		t.Error("invalid values recorded")
+	}
+}
@@ -0,0 +1,227 @@
+//go:build js
+
+package mockup
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/gopherjs/jquery"
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// clipboard flavors of copied elements. The mockup document is also copied as plain text,
+// it can then be pasted in another tab or in another mockup.
+const (
+	clipboardMockup = "application/x-mockup+json"
+	clipboardSvg    = "image/svg+xml"
+	clipboardText   = "text/plain"
+)
+
+// clip is the mockup document last copied or pasted, pasting it again places it further
+type clip struct {
+	data   string
+	pastes int
+}
+
+// Copy returns the selected elements as a mockup document and as svg markup, it reports false when nothing is selected
+func (ed *ControlEditable) Copy() (data []byte, markup string, ok bool) {
+	elements := []MockupElement{}
+	for _, wrapper := range ed.doc.Selected() {
+		elements = append(elements, unwrap(wrapper))
+	}
+	if len(elements) == 0 {
+		return nil, "", false
+	}
+	data, err := Marshal(elements)
+	if err != nil {
+		console.Call("error", err.Error())
+		return nil, "", false
+	}
+	ed.clip = clip{data: string(data)}
+	return data, Render(elements, 0).String(), true
+}
+
+// Cut copies the selected elements and removes them, they are pasted back in place
+func (ed *ControlEditable) Cut() (data []byte, markup string, ok bool) {
+	data, markup, ok = ed.Copy()
+	if ok {
+		ed.Delete()
+		ed.clip.pastes = -1
+	}
+	return data, markup, ok
+}
+
+// Paste adds the elements of a mockup document or of svg markup on top of the scope, with fresh ids, and selects them.
+// Pasted elements are placed off the copied ones, further each time the same elements are pasted.
+// It reports false when data is neither.
+func (ed *ControlEditable) Paste(data string) bool {
+	elements, err := Unmarshal([]byte(data))
+	if err != nil {
+		elements, err = importSvg(data)
+	}
+	if err != nil || len(elements) == 0 {
+		return false
+	}
+	if ed.clip.data != data {
+		ed.clip = clip{data: data}
+	}
+	ed.clip.pastes++
+	ed.addCopies(elements, float64(ed.clip.pastes)*copyOffset)
+	return true
+}
+
+// addCopies adds copies of the elements with fresh ids, moved by offset, on top of the scope and selects them.
+// They are added in a single history entry.
+func (ed *ControlEditable) addCopies(elements []MockupElement, offset float64) {
+	commands := []Command{}
+	ids := []string{}
+	for _, e := range elements {
+		ele, err := Clone(e, ed.doc.NewId("M"))
+		if err != nil {
+			console.Call("error", err.Error())
+			continue
+		}
+		shiftBy(ele, offset, offset)
+		// added one by one, each copy gets a fresh id
+		c := newAddCommand(ed.doc, ed.doc.scopeId(), len(ed.doc.Scope()), ele)
+		c.Do()
+		commands = append(commands, c)
+		ids = append(ids, ele.Id())
+	}
+	if len(commands) == 0 {
+		return
+	}
+	ed.History.Push(newBatchCommand(commands...))
+	ed.doc.SelectOnly(ids...)
+}
+
+// clipboardCopy puts the selected elements on the clipboard, unless text is selected in a field
+func (ed *ControlEditable) clipboardCopy(e jquery.Event) {
+	ed.clipboardWrite(e, ed.Copy)
+}
+
+func (ed *ControlEditable) clipboardCut(e jquery.Event) {
+	ed.clipboardWrite(e, ed.Cut)
+}
+
+func (ed *ControlEditable) clipboardWrite(e jquery.Event, write func() ([]byte, string, bool)) {
+	if ed.typing(e) {
+		return
+	}
+	data, markup, ok := write()
+	if !ok {
+		return
+	}
+	cd := e.Get("originalEvent").Get("clipboardData")
+	cd.Call("setData", clipboardMockup, string(data))
+	cd.Call("setData", clipboardText, string(data))
+	cd.Call("setData", clipboardSvg, markup)
+	e.PreventDefault()
+}
+
+// clipboardPaste pastes the richest flavor of the clipboard that holds elements
+func (ed *ControlEditable) clipboardPaste(e jquery.Event) {
+	if ed.typing(e) {
+		return
+	}
+	cd := e.Get("originalEvent").Get("clipboardData")
+	for _, flavor := range []string{clipboardMockup, clipboardSvg, clipboardText} {
+		if data := jsString(cd.Call("getData", flavor)); data != "" && ed.Paste(data) {
+			e.PreventDefault()
+			return
+		}
+	}
+}
+
+var errNotSvg = errors.New("not svg markup")
+
+// importSvg turns svg markup into mockup elements, with temporary ids. Rects become boxes, lines lines
+// and texts labels. Other shapes become boxes of their size, groups groups.
+func importSvg(markup string) ([]MockupElement, error) {
+	if !strings.HasPrefix(strings.TrimSpace(markup), "<") {
+		return nil, errNotSvg
+	}
+	doc, err := svg.ParseString(markup)
+	if err != nil {
+		return nil, err
+	}
+	return importAll(doc.Content, svg.Identity), nil
+}
+
+func importAll(content []svg.SvgElement, m svg.Matrix) []MockupElement {
+	elements := []MockupElement{}
+	for _, se := range content {
+		if ele, ok := importElement(se, m); ok {
+			elements = append(elements, ele)
+		}
+	}
+	return elements
+}
+
+// importElement turns the svg element, drawn through m, into a mockup element
+func importElement(se svg.SvgElement, m svg.Matrix) (MockupElement, bool) {
+	const id = "import"
+	e := svg.DRAGGABLE | svg.EDITABLE
+	switch se := se.(type) {
+	case *svg.Group:
+		children := importAll(se.Content, m.Mul(se.Transformable.Matrix()))
+		if len(children) == 0 {
+			return nil, false
+		}
+		return NewGroup(children, id, e), true
+	case *svg.Line:
+		t := m.Mul(se.Transformable.Matrix())
+		p1, p2 := t.Apply(svg.NewPoint(se.X1, se.Y1)), t.Apply(svg.NewPoint(se.X2, se.Y2))
+		l := NewLine(p2.X()-p1.X(), p2.Y()-p1.Y(), p1.X(), p1.Y(), id)
+		l.Stroke = importStroke(se.Strokeable, l.Stroke)
+		return l, true
+	case *svg.Text:
+		b := m.BBox(se.BBox())
+		content := []string{se.Content}
+		for _, span := range se.Spans {
+			content = append(content, span.Content)
+		}
+		l := NewLabel(b.Width, b.Height, b.X, b.Y, strings.TrimSpace(strings.Join(content, "\n")), id, e)
+		if c := se.Fillable.Fill; c != "" && c != "none" {
+			l.Text.Color = c
+		}
+		return l, true
+	case *svg.Rect:
+		b := m.BBox(se.BBox())
+		x := NewBox(b.Width, b.Height, b.X, b.Y, id, e)
+		x.Stroke = importStroke(se.Strokeable, x.Stroke)
+		x.Fill = importFill(se.Fillable.Fill, se.Fillable.Opacity, x.Fill)
+		return x, true
+	}
+	b := m.BBox(se.BBox())
+	if b.Width == 0 && b.Height == 0 {
+		return nil, false
+	}
+	return NewBox(b.Width, b.Height, b.X, b.Y, id, e), true
+}
+
+// importStroke is the stroke of the element, the nearest thickness to its width, def where it has none
+func importStroke(s svg.Strokeable, def Stroke) Stroke {
+	if s.Stroke == "none" {
+		return Stroke{Color: def.Color, Thickness: None}
+	}
+	if s.Stroke != "" {
+		def.Color = s.Stroke
+	}
+	if s.StrokeWidth > 0 {
+		def.Thickness = Thickness(math.Max(1, math.Min(float64(VeryThick), math.Round(s.StrokeWidth/VeryThin.Float64()))))
+	}
+	return def
+}
+
+func importFill(color string, opacity float64, def Fill) Fill {
+	switch color {
+	case "":
+		return def
+	case "none":
+		return Fill{Color: def.Color, Opacity: 0}
+	}
+	return Fill{Color: color, Opacity: opacity}
+}
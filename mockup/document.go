@@ -0,0 +1,500 @@
+package mockup
+
+import (
+	"strconv"
+)
+
+type ChangeKind int
+
+const (
+	ADDED ChangeKind = iota
+	REMOVED
+	REPLACED
+	SELECTED
+	DESELECTED
+	REFRAMED
+	MOVED
+)
+
+var changeKindString = []string{"added", "removed", "replaced", "selected", "deselected", "reframed", "moved"}
+
+func (kind ChangeKind) String() string {
+	return changeKindString[kind]
+}
+
+// Change describes a single mutation of a Document.
+// For REPLACED, SELECTED and DESELECTED, Old is the element that was on the page
+// before the change and Element is the one that took its place.
+// For REFRAMED, Old is the SelectionBox removed from the page and Element the one added, either may be nil.
+// For MOVED, Element changed its z-order and is given as it is on the page, its wrapper when it is selected.
+// Parent is the group holding Element, nil for the content of the document.
+// For ADDED and MOVED, Above is the element right above Element in the z-order, as it is on the page,
+// nil when Element is on top.
+type Change struct {
+	Kind    ChangeKind
+	Element MockupElement
+	Old     MockupElement
+	Parent  MockupElement
+	Above   MockupElement
+	Index   int
+}
+
+// Document is an ordered collection of mockup elements, the first element is at the bottom of the z-order.
+// Selection wrappers (ScaleBox, ScaleLine, SelectBox) are kept apart from the content,
+// a multiple selection is framed by a SelectionBox.
+// Groups may be entered to edit their children, selection and insertion then apply to the children
+// of the entered group, the scope of the document.
+type Document struct {
+	elements  []MockupElement
+	selection map[string]MockupElement
+	box       *SelectionBox
+	// scope are the ids of the entered groups, outermost first
+	scope     []string
+	listeners []func(Change)
+}
+
+func NewDocument(elements ...MockupElement) *Document {
+	return &Document{
+		elements:  append([]MockupElement{}, elements...),
+		selection: map[string]MockupElement{},
+	}
+}
+
+// OnChange registers fn to be called after every change of the document
+func (doc *Document) OnChange(fn func(Change)) {
+	doc.listeners = append(doc.listeners, fn)
+}
+
+func (doc *Document) emit(c Change) {
+	for _, fn := range doc.listeners {
+		fn(c)
+	}
+}
+
+// Elements returns the content in z-order, bottom first
+func (doc *Document) Elements() []MockupElement {
+	return append([]MockupElement{}, doc.elements...)
+}
+
+func (doc *Document) Len() int {
+	return len(doc.elements)
+}
+
+// Scope returns the elements that can be selected in z-order, the children of the entered group or the content
+func (doc *Document) Scope() []MockupElement {
+	return append([]MockupElement{}, doc.children(doc.scopeId())...)
+}
+
+// scopeId is the id of the entered group, empty for the content
+func (doc *Document) scopeId() string {
+	if len(doc.scope) == 0 {
+		return ""
+	}
+	return doc.scope[len(doc.scope)-1]
+}
+
+// children returns the elements of the group with the id, the content for an empty id
+func (doc *Document) children(parent string) []MockupElement {
+	if parent == "" {
+		return doc.elements
+	}
+	if ele, ok := doc.Get(parent); ok {
+		if g, ok := ele.(*group); ok {
+			return g.children
+		}
+	}
+	return nil
+}
+
+func (doc *Document) setChildren(parent string, elements []MockupElement) {
+	if parent == "" {
+		doc.elements = elements
+		return
+	}
+	if ele, ok := doc.Get(parent); ok {
+		if g, ok := ele.(*group); ok {
+			g.children = elements
+		}
+	}
+}
+
+// find returns the group holding the element with the id, nil for the content, and the index of the element in it.
+// The index is -1 when the document has no such element.
+func (doc *Document) find(id string) (*group, int) {
+	return findIn(nil, doc.elements, id)
+}
+
+func findIn(parent *group, elements []MockupElement, id string) (*group, int) {
+	for k, v := range elements {
+		if v.Id() == id {
+			return parent, k
+		}
+		if g, ok := v.(*group); ok {
+			if p, i := findIn(g, g.children, id); i >= 0 {
+				return p, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// parentId is the id of the group, empty for the content
+func parentId(g *group) string {
+	if g == nil {
+		return ""
+	}
+	return g.Id()
+}
+
+// parentChange sets the parent of the change to the group with the id
+func (doc *Document) parentChange(c Change, parent string) Change {
+	if ele, ok := doc.Get(parent); ok && parent != "" {
+		c.Parent = ele
+	}
+	return c
+}
+
+// IndexOf returns the z-order index of the element with the id in the scope, or -1
+func (doc *Document) IndexOf(id string) int {
+	for k, v := range doc.children(doc.scopeId()) {
+		if v.Id() == id {
+			return k
+		}
+	}
+	return -1
+}
+
+// InScope reports whether the element with the id can be selected, see Scope
+func (doc *Document) InScope(id string) bool {
+	return doc.IndexOf(id) >= 0
+}
+
+// Get returns the element with the id, at any depth of groups
+func (doc *Document) Get(id string) (MockupElement, bool) {
+	if p, i := doc.find(id); i >= 0 {
+		return doc.children(parentId(p))[i], true
+	}
+	return nil, false
+}
+
+// Lookup returns the element of the scope, the selection wrapper or the SelectionBox with the id
+func (doc *Document) Lookup(id string) (MockupElement, bool) {
+	if ele, ok := doc.selection[id]; ok {
+		return ele, true
+	}
+	if doc.box != nil && doc.box.Id() == id {
+		return doc.box, true
+	}
+	if i := doc.IndexOf(id); i >= 0 {
+		return doc.children(doc.scopeId())[i], true
+	}
+	return nil, false
+}
+
+// NewId returns an id starting with prefix that is not used by the document
+func (doc *Document) NewId(prefix string) string {
+	for i := doc.Len(); ; i++ {
+		id := prefix + strconv.Itoa(i)
+		_, used := doc.Get(id)
+		if _, ok := doc.Lookup(id); !ok && !used {
+			return id
+		}
+	}
+}
+
+// Add puts the element on top of the z-order of the scope
+func (doc *Document) Add(ele MockupElement) {
+	doc.Insert(len(doc.children(doc.scopeId())), ele)
+}
+
+// Insert puts the element at z-order index i of the scope
+func (doc *Document) Insert(i int, ele MockupElement) {
+	doc.insertInto(doc.scopeId(), i, ele)
+}
+
+// insertInto puts the element at z-order index i of the group with the id, or of the content
+func (doc *Document) insertInto(parent string, i int, ele MockupElement) {
+	elements := doc.children(parent)
+	if i < 0 || i > len(elements) {
+		i = len(elements)
+	}
+	elements = append(elements, nil)
+	copy(elements[i+1:], elements[i:])
+	elements[i] = ele
+	doc.setChildren(parent, elements)
+	doc.emit(doc.parentChange(Change{Kind: ADDED, Element: ele, Above: doc.above(parent, i), Index: i}, parent))
+}
+
+// Reorder moves the element with the id to z-order index i of the group holding it, or of the content
+func (doc *Document) Reorder(id string, i int) bool {
+	p, from := doc.find(id)
+	if from < 0 {
+		return false
+	}
+	parent := parentId(p)
+	elements := doc.children(parent)
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(elements) {
+		i = len(elements) - 1
+	}
+	if i == from {
+		return true
+	}
+	ele := elements[from]
+	elements = append(elements[:from], elements[from+1:]...)
+	elements = append(elements, nil)
+	copy(elements[i+1:], elements[i:])
+	elements[i] = ele
+	doc.setChildren(parent, elements)
+	doc.emit(doc.parentChange(Change{Kind: MOVED, Element: doc.onPage(ele), Above: doc.above(parent, i), Index: i}, parent))
+	return true
+}
+
+// above is the element right above index i of the group with the id, as it is on the page, nil on top
+func (doc *Document) above(parent string, i int) MockupElement {
+	elements := doc.children(parent)
+	if i+1 >= len(elements) {
+		return nil
+	}
+	return doc.onPage(elements[i+1])
+}
+
+// onPage is the element as it is drawn, its wrapper when it is selected
+func (doc *Document) onPage(ele MockupElement) MockupElement {
+	if wrapper, ok := doc.selection[EditablePrefix+ele.Id()]; ok {
+		return wrapper
+	}
+	return ele
+}
+
+// Remove deletes the element with the id wherever it is, deselecting it first
+func (doc *Document) Remove(id string) (MockupElement, bool) {
+	p, i := doc.find(id)
+	if i < 0 {
+		return nil, false
+	}
+	doc.Deselect(id)
+	parent := parentId(p)
+	elements := doc.children(parent)
+	ele := elements[i]
+	doc.setChildren(parent, append(elements[:i], elements[i+1:]...))
+	doc.emit(doc.parentChange(Change{Kind: REMOVED, Element: ele, Index: i}, parent))
+	// groups entered inside a removed group are left
+	for k, id := range doc.scope {
+		if _, ok := doc.Get(id); !ok {
+			doc.ClearSelection()
+			doc.scope = doc.scope[:k]
+			break
+		}
+	}
+	return ele, true
+}
+
+// Replace swaps the element with the id for ele wherever it is, keeping its z-order
+func (doc *Document) Replace(id string, ele MockupElement) bool {
+	p, i := doc.find(id)
+	if i < 0 {
+		return false
+	}
+	doc.Deselect(id)
+	parent := parentId(p)
+	elements := doc.children(parent)
+	old := elements[i]
+	elements[i] = ele
+	doc.emit(doc.parentChange(Change{Kind: REPLACED, Element: ele, Old: old, Index: i}, parent))
+	return true
+}
+
+// Enter makes the children of the group with the id the scope, the group must be in the scope
+func (doc *Document) Enter(id string) bool {
+	ele, ok := doc.Lookup(id)
+	if !ok {
+		return false
+	}
+	g, ok := unwrap(ele).(*group)
+	if !ok {
+		return false
+	}
+	doc.ClearSelection()
+	// the children are edited where they are drawn
+	g.flatten()
+	doc.scope = append(doc.scope, id)
+	return true
+}
+
+// Exit leaves the entered group, its parent becomes the scope
+func (doc *Document) Exit() bool {
+	if len(doc.scope) == 0 {
+		return false
+	}
+	doc.ClearSelection()
+	doc.scope = doc.scope[:len(doc.scope)-1]
+	return true
+}
+
+// ExitAll leaves every entered group, the content becomes the scope
+func (doc *Document) ExitAll() {
+	for doc.Exit() {
+	}
+}
+
+// newSelectionWrapper wraps an element for editing, elements of a multiple selection are only outlined
+func newSelectionWrapper(ele MockupElement, multiple bool) MockupElement {
+	if multiple {
+		return NewSelectBox(ele)
+	}
+	if _, ok := ele.(*line); ok {
+		return NewScaleLine(ele)
+	}
+	return NewScaleBox(ele)
+}
+
+// Select adds the content element with the id to the selection, wrapping it for editing, and returns the wrapper
+func (doc *Document) Select(id string) (MockupElement, bool) {
+	if wrapper, ok := doc.selection[EditablePrefix+id]; ok {
+		return wrapper, true
+	}
+	i := doc.IndexOf(id)
+	if i < 0 {
+		return nil, false
+	}
+	ele := doc.children(doc.scopeId())[i]
+	wrapper := newSelectionWrapper(ele, len(doc.selection) > 0)
+	doc.selection[wrapper.Id()] = wrapper
+	doc.emit(Change{Kind: SELECTED, Element: wrapper, Old: ele, Index: i})
+	doc.reframe()
+	return doc.selection[wrapper.Id()], true
+}
+
+// Deselect unwraps the element, id may be the one of the element or of its wrapper
+func (doc *Document) Deselect(id string) {
+	wrapper, ok := doc.selection[id]
+	if !ok {
+		id = EditablePrefix + id
+		if wrapper, ok = doc.selection[id]; !ok {
+			return
+		}
+	}
+	delete(doc.selection, id)
+	ele := unwrap(wrapper)
+	doc.emit(Change{Kind: DESELECTED, Element: ele, Old: wrapper, Index: doc.IndexOf(ele.Id())})
+	doc.reframe()
+}
+
+// Toggle selects the element with the id, or deselects it when it is selected
+func (doc *Document) Toggle(id string) {
+	if doc.IsSelected(id) {
+		doc.Deselect(id)
+	} else {
+		doc.Select(id)
+	}
+}
+
+// SelectOnly makes the elements with the ids the whole selection
+func (doc *Document) SelectOnly(ids ...string) {
+	keep := map[string]bool{}
+	for _, id := range ids {
+		keep[id] = true
+	}
+	for _, wrapper := range doc.Selected() {
+		if !keep[unwrap(wrapper).Id()] {
+			doc.Deselect(wrapper.Id())
+		}
+	}
+	for _, id := range ids {
+		doc.Select(id)
+	}
+}
+
+// SelectionBox returns the box framing a multiple selection
+func (doc *Document) SelectionBox() (*SelectionBox, bool) {
+	return doc.box, doc.box != nil
+}
+
+// reframe matches the wrappers to the size of the selection. A single element gets its
+// controls, the elements of a multiple selection are outlined and framed together by a SelectionBox.
+func (doc *Document) reframe() {
+	multiple := len(doc.selection) > 1
+	for _, wrapper := range doc.Selected() {
+		if _, outlined := wrapper.(*SelectBox); outlined == multiple {
+			continue
+		}
+		ele := unwrap(wrapper)
+		rewrapped := newSelectionWrapper(ele, multiple)
+		doc.selection[wrapper.Id()] = rewrapped
+		doc.emit(Change{Kind: SELECTED, Element: rewrapped, Old: wrapper, Index: doc.IndexOf(ele.Id())})
+	}
+
+	old := doc.box
+	doc.box = nil
+	if multiple {
+		doc.box = NewSelectionBox(doc.Selected())
+	}
+	if old == nil && doc.box == nil {
+		return
+	}
+	c := Change{Kind: REFRAMED, Index: -1}
+	if old != nil {
+		c.Old = old
+	}
+	if doc.box != nil {
+		c.Element = doc.box
+	}
+	doc.emit(c)
+}
+
+func (doc *Document) ClearSelection() {
+	for _, wrapper := range doc.Selected() {
+		doc.Deselect(wrapper.Id())
+	}
+}
+
+// Selected returns the selection wrappers in z-order
+func (doc *Document) Selected() []MockupElement {
+	selected := []MockupElement{}
+	for _, ele := range doc.children(doc.scopeId()) {
+		if wrapper, ok := doc.selection[EditablePrefix+ele.Id()]; ok {
+			selected = append(selected, wrapper)
+		}
+	}
+	return selected
+}
+
+func (doc *Document) IsSelected(id string) bool {
+	_, ok := doc.selection[EditablePrefix+id]
+	return ok
+}
+
+// Clone returns a copy of ele with a new id, the children of a group get ids made from it
+func Clone(ele MockupElement, id string) (MockupElement, error) {
+	rec, err := newElementRecord(ele)
+	if err != nil {
+		return nil, err
+	}
+	rec.rename(id)
+	return rec.element()
+}
+
+// MarshalJSON encodes the content of the document in the mockup format
+func (doc *Document) MarshalJSON() ([]byte, error) {
+	return Marshal(doc.elements)
+}
+
+// UnmarshalJSON replaces the content of the document with the decoded elements
+func (doc *Document) UnmarshalJSON(data []byte) error {
+	elements, err := Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	doc.ExitAll()
+	doc.ClearSelection()
+	for doc.Len() > 0 {
+		doc.Remove(doc.elements[doc.Len()-1].Id())
+	}
+	for _, ele := range elements {
+		doc.Add(ele)
+	}
+	return nil
+}
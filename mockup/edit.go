@@ -1,3 +1,5 @@
+//go:build js
+
 package mockup
 
 import (
@@ -39,7 +41,38 @@ type ControlEditable struct {
 	Scalable
 	LineMovable
 	Clonable
+	Rotatable
 	Border
+	// Grid snaps the elements edited on the canvas, nil when there is none
+	Grid    *Grid
+	Keymap  Keymap
+	History *History
+	doc     *Document
+	toolbar *Document
+	gesture *gesture
+	inline  *inlineEditor
+	marquee *marquee
+	dragged bool
+	clip    clip
+}
+
+// gesture holds the elements edited by the current mouse gesture, with their geometry before the gesture started,
+// and the record of the groups among them. A clone gesture adds the element with the id.
+// The other elements of the scope are indexed for the alignment guides.
+type gesture struct {
+	before  map[string]BaseElement
+	records map[string]elementRecord
+	id      string
+	clone   bool
+	guides  *guideIndex
+}
+
+// marquee is the rubber band selecting the elements it touches, from where the mouse went down
+type marquee struct {
+	x        float64
+	y        float64
+	additive bool
+	rect     *svg.Rect
 }
 
 type Border struct {
@@ -55,6 +88,9 @@ func NewControlEditable(x1, y1, x2, y2 float64) *ControlEditable {
 		Scalable:    scalableNill,
 		LineMovable: lineMovableNil,
 		Clonable:    clonableNil,
+		Rotatable:   rotatableNil,
+		Keymap:      DefaultKeymap(),
+		History:     NewHistory(),
 		Border: Border{
 			X1: x1,
 			Y1: y1,
@@ -80,55 +116,219 @@ type Clonable struct {
 	jquery.JQuery
 }
 
+type Rotatable struct {
+	jquery.JQuery
+}
+
 var movableNil = Movable{JQuery: jQuery(nil)}
 var scalableNill = Scalable{JQuery: jQuery(nil)}
 var lineMovableNil = LineMovable{JQuery: jQuery(nil)}
 var clonableNil = Clonable{JQuery: jQuery(nil)}
+var rotatableNil = Rotatable{JQuery: jQuery(nil)}
+
+// BindEvents makes the elements of doc editable, new elements are cloned from the toolbar
+func (ed *ControlEditable) BindEvents(doc *Document, toolbar *Document) {
+	ed.doc = doc
+	ed.toolbar = toolbar
 
-func (ed *ControlEditable) BindEvents(m map[string]MockupElement) {
 	//dragging
-	jQuery(document).On(jquery.MOUSEDOWN, svg.DRAGGABLE.JqSelector(), ed.startDragging)
-	jQuery(document).On(jquery.MOUSEMOVE, func(e jquery.Event) { ed.dragging(e, m) })
+	jQuery(document).On(jquery.MOUSEDOWN, svg.DRAGGABLE.JQSelector(), ed.startDragging)
+	jQuery(document).On(jquery.MOUSEMOVE, ed.dragging)
 
 	//scaling
-	jQuery(document).On(jquery.MOUSEOVER, svg.EW_RESIZABLE.JqSelector(), ed.ewResizeMouseOver)
-	jQuery(document).On(jquery.MOUSEOVER, svg.NS_RESIZABLE.JqSelector(), ed.nsResizeMouseOver)
-	jQuery(document).On(jquery.MOUSEOVER, svg.NESW_RESIZABLE.JqSelector(), ed.neswResizeMouseOver)
-	jQuery(document).On(jquery.MOUSEOVER, svg.NWSE_RESIZABLE.JqSelector(), ed.nwseResizeMouseOver)
+	jQuery(document).On(jquery.MOUSEOVER, svg.EW_RESIZABLE.JQSelector(), ed.ewResizeMouseOver)
+	jQuery(document).On(jquery.MOUSEOVER, svg.NS_RESIZABLE.JQSelector(), ed.nsResizeMouseOver)
+	jQuery(document).On(jquery.MOUSEOVER, svg.NESW_RESIZABLE.JQSelector(), ed.neswResizeMouseOver)
+	jQuery(document).On(jquery.MOUSEOVER, svg.NWSE_RESIZABLE.JQSelector(), ed.nwseResizeMouseOver)
 
-	jQuery(document).On(jquery.MOUSEDOWN, svg.NWSE_RESIZABLE.JqSelector(), ed.startResize)
-	jQuery(document).On(jquery.MOUSEDOWN, svg.NESW_RESIZABLE.JqSelector(), ed.startResize)
-	jQuery(document).On(jquery.MOUSEDOWN, svg.NS_RESIZABLE.JqSelector(), ed.startResize)
-	jQuery(document).On(jquery.MOUSEDOWN, svg.EW_RESIZABLE.JqSelector(), ed.startResize)
+	jQuery(document).On(jquery.MOUSEDOWN, svg.NWSE_RESIZABLE.JQSelector(), ed.startResize)
+	jQuery(document).On(jquery.MOUSEDOWN, svg.NESW_RESIZABLE.JQSelector(), ed.startResize)
+	jQuery(document).On(jquery.MOUSEDOWN, svg.NS_RESIZABLE.JQSelector(), ed.startResize)
+	jQuery(document).On(jquery.MOUSEDOWN, svg.EW_RESIZABLE.JQSelector(), ed.startResize)
+
+	//rotating
+	jQuery(document).On(jquery.MOUSEOVER, svg.ROTATABLE.JQSelector(), ed.rotateMouseOver)
+	jQuery(document).On(jquery.MOUSEDOWN, svg.ROTATABLE.JQSelector(), ed.startRotate)
 
 	//line moving
-	jQuery(document).On(jquery.MOUSEDOWN, svg.LINE_VERTEX.JqSelector(), ed.startLineEditing)
+	jQuery(document).On(jquery.MOUSEDOWN, svg.LINE_VERTEX.JQSelector(), ed.startLineEditing)
 
 	// stopping
 	jQuery(document).On(jquery.MOUSEUP, ed.stopDraggingResize)
 
 	// clonable
-	jQuery(document).On(jquery.MOUSEDOWN, svg.CLONABLE.JqSelector(), func(e jquery.Event) {
-		ed.startClone(e, m)
+	jQuery(document).On(jquery.MOUSEDOWN, svg.CLONABLE.JQSelector(), ed.startClone)
+
+	// selecting
+	jQuery(document).On(jquery.MOUSEDOWN, svg.SELECTION_AREA.JQSelector(), ed.startMarquee)
+	jQuery(document).On(jquery.DBLCLICK, svg.SELECTION_AREA.JQSelector(), func(e jquery.Event) {
+		ed.doc.ExitAll()
 	})
 
+	// text editing
+	jQuery(document).On(jquery.DBLCLICK, svg.EDITABLE.JQSelector(), ed.startTextEditing)
+
+	// shortcuts
+	jQuery(document).On(jquery.KEYDOWN, ed.keyDown)
+
+	// clipboard
+	jQuery(document).On("copy", ed.clipboardCopy)
+	jQuery(document).On("cut", ed.clipboardCut)
+	jQuery(document).On("paste", ed.clipboardPaste)
+}
+
+// Group gathers the selected elements into a group, which is then selected
+func (ed *ControlEditable) Group() {
+	selected := ed.doc.Selected()
+	if len(selected) < 2 {
+		return
+	}
+	ids := make([]string, len(selected))
+	for k, wrapper := range selected {
+		ids[k] = unwrap(wrapper).Id()
+	}
+	id := ed.doc.NewId("G")
+	c, ok := newGroupCommand(ed.doc, id, svg.EDITABLE|svg.DRAGGABLE, ids)
+	if !ok {
+		return
+	}
+	ed.History.Do(c)
+	ed.doc.SelectOnly(id)
+}
+
+// Ungroup puts the children of the selected groups in their place, the children are then selected
+func (ed *ControlEditable) Ungroup() {
+	commands := []Command{}
+	ids := []string{}
+	for _, wrapper := range ed.doc.Selected() {
+		g, ok := unwrap(wrapper).(*group)
+		if !ok {
+			continue
+		}
+		if c, ok := newUngroupCommand(ed.doc, g.Id()); ok {
+			commands = append(commands, c)
+			for _, child := range g.children {
+				ids = append(ids, child.Id())
+			}
+		}
+	}
+	if len(commands) == 0 {
+		return
+	}
+	ed.History.Do(newBatchCommand(commands...))
+	ed.doc.SelectOnly(ids...)
+}
+
+// startGesture starts editing the element with the id, or every selected element
+// when it is part of a multiple selection
+func (ed *ControlEditable) startGesture(id string) {
+	ed.gesture = nil
+	ele, ok := ed.doc.Lookup(id)
+	if !ok {
+		return
+	}
+	targets := []MockupElement{ele}
+	switch ele.(type) {
+	case *SelectBox, *SelectionBox:
+		targets = ed.doc.Selected()
+	}
+	g := newGesture(targets)
+	g.guides = ed.guidesOf(g.before)
+	ed.gesture = g
+}
+
+// newGesture remembers the geometry of the elements, they may be selection wrappers
+func newGesture(targets []MockupElement) *gesture {
+	g := &gesture{before: map[string]BaseElement{}, records: map[string]elementRecord{}}
+	for _, t := range targets {
+		t = unwrap(t)
+		g.before[t.Id()] = t.GetBase()
+		if _, ok := t.(*group); ok {
+			if rec, err := newElementRecord(t); err == nil {
+				g.records[t.Id()] = rec
+			}
+		}
+	}
+	return g
 }
 
-func (ed *ControlEditable) startClone(e jquery.Event, m map[string]MockupElement) {
+// stopGesture records the completed gesture as a single history entry
+func (ed *ControlEditable) stopGesture() {
+	g := ed.gesture
+	ed.gesture = nil
+	ed.dragged = false
+	if g == nil {
+		return
+	}
+	if g.clone {
+		if ele, ok := ed.doc.Get(g.id); ok {
+			ed.History.Push(newAddCommand(ed.doc, ed.doc.scopeId(), ed.doc.IndexOf(g.id), ele))
+		}
+		return
+	}
+	ed.dragged = ed.record(g)
+}
+
+// record pushes the changes made by the gesture as a single history entry, it reports false when there are none
+func (ed *ControlEditable) record(g *gesture) bool {
+	commands := []Command{}
+	for _, ele := range ed.doc.Scope() {
+		before, ok := g.before[ele.Id()]
+		if !ok {
+			continue
+		}
+		after := ele.GetBase()
+		if after == before {
+			continue
+		}
+		// a group is restored child by child
+		if rec, ok := g.records[ele.Id()]; ok {
+			if now, err := newElementRecord(ele); err == nil {
+				commands = append(commands, newRecordCommand(ed.doc, rec, now))
+			}
+			continue
+		}
+		commands = append(commands, newGeometryCommand(ed.doc, ele.Id(), before, after))
+	}
+	switch len(commands) {
+	case 0:
+		return false
+	case 1:
+		ed.History.Push(commands[0])
+	default:
+		ed.History.Push(newBatchCommand(commands...))
+	}
+	return true
+}
+
+// Dragged reports whether the last mouse gesture changed the document,
+// the click ending such a gesture doesn't change the selection
+func (ed *ControlEditable) Dragged() bool {
+	return ed.dragged
+}
+
+func (ed *ControlEditable) startClone(e jquery.Event) {
 	ed.Movable = movableNil
 	ed.Scalable = scalableNill
 	ed.LineMovable = lineMovableNil
+	ed.Rotatable = rotatableNil
 	id := jQuery(e.CurrentTarget).Attr("id")
-	ele := m[id]
+	tool, ok := ed.toolbar.Get(id)
+	if !ok {
+		return
+	}
 
-	count := len(m)
-	nid := "M" + jsString(count)
-	clo := newCloneBox(ele, nid)
-	m[nid] = clo
-	cloJq := clo.Svg().Jq()
-	jQuery("svg").Append(cloJq)
+	ele, err := Clone(tool, ed.doc.NewId("M"))
+	if err != nil {
+		console.Call("error", err.Error())
+		return
+	}
+	clo := newCloneBox(ele)
+	ed.doc.Add(clo.MockupElement)
+	ed.gesture = &gesture{id: clo.Id(), clone: true}
+	ed.gesture.guides = ed.guidesOf(map[string]BaseElement{clo.Id(): clo.GetBase()})
 
-	ed.Clonable = Clonable{JQuery: cloJq}
+	ed.Clonable = Clonable{JQuery: jQuery("#" + clo.Id())}
 }
 
 func (ed *ControlEditable) startResize(e jquery.Event) {
@@ -136,6 +336,8 @@ func (ed *ControlEditable) startResize(e jquery.Event) {
 	ed.Scalable = Scalable{JQuery: jQuery(e.CurrentTarget)}
 	ed.LineMovable = lineMovableNil
 	ed.Clonable = clonableNil
+	ed.Rotatable = rotatableNil
+	ed.startGesture(ed.Scalable.Attr("id")[4:])
 }
 
 func (ed *ControlEditable) startLineEditing(e jquery.Event) {
@@ -143,6 +345,21 @@ func (ed *ControlEditable) startLineEditing(e jquery.Event) {
 	ed.Scalable = scalableNill
 	ed.LineMovable = LineMovable{JQuery: jQuery(e.CurrentTarget)}
 	ed.Clonable = clonableNil
+	ed.Rotatable = rotatableNil
+	ed.startGesture(ed.LineMovable.Attr("id")[5:])
+}
+
+func (ed *ControlEditable) startRotate(e jquery.Event) {
+	ed.Movable = movableNil
+	ed.Scalable = scalableNill
+	ed.LineMovable = lineMovableNil
+	ed.Clonable = clonableNil
+	ed.Rotatable = Rotatable{JQuery: jQuery(e.CurrentTarget)}
+	ed.startGesture(ed.Rotatable.Attr("id")[4:])
+}
+
+func (ed *ControlEditable) rotateMouseOver(e jquery.Event) {
+	jQuery(e.CurrentTarget).SetCss("cursor", "crosshair")
 }
 
 func (ed *ControlEditable) ewResizeMouseOver(e jquery.Event) {
@@ -161,64 +378,95 @@ func (ed *ControlEditable) nwseResizeMouseOver(e jquery.Event) {
 	jQuery(e.CurrentTarget).SetCss("cursor", "nwse-resize")
 }
 
-func (ed *ControlEditable) dragging(e jquery.Event, m map[string]MockupElement) {
+func (ed *ControlEditable) dragging(e jquery.Event) {
 	clientX := e.Get("offsetX").Float()
 	clientY := e.Get("offsetY").Float()
 
 	if ed.Movable != movableNil {
-		id := ed.Movable.Attr("id")
-		ele := m[id]
-		width := float64(ed.Movable.Width())
-		height := float64(ed.Movable.Height())
-		if clientX-width >= ed.X1 && clientX < ed.X2 && clientY-height >= ed.Y1 && clientY < ed.Y2 {
-			ele.MoveTo(clientX-width, clientY)
+		ele, ok := ed.doc.Lookup(ed.Movable.Attr("id"))
+		if !ok {
+			return
+		}
+		if _, ok := ele.(*SelectBox); ok {
+			// an element of a multiple selection moves the whole selection
+			if box, ok := ed.doc.SelectionBox(); ok {
+				ele = box
+			}
+		}
+		b := ele.Svg().BBox()
+		width, height := b.Width, b.Height
+		x, y := ed.align(e, ele, clientX-width, clientY)
+		if x >= ed.X1 && x+width < ed.X2 && y-height >= ed.Y1 && y < ed.Y2 {
+			ele.MoveTo(x, y)
 		}
 	}
 
 	if ed.Scalable != scalableNill {
 		id := ed.Scalable.Attr("id")
 		sqr := id[2:3]
-		id = id[4:]
-		ele := m[id]
-		switch sqr {
-		case "1":
-			ele.(*ScaleBox).NWResizeTo(clientX, clientY)
-		case "2":
-			ele.(*ScaleBox).NResizeTo(clientX, clientY)
-		case "3":
-			ele.(*ScaleBox).NEResizeTo(clientX, clientY)
-		case "4":
-			ele.(*ScaleBox).WResizeTo(clientX, clientY)
-		case "5":
-			ele.(*ScaleBox).EResizeTo(clientX, clientY)
-		case "6":
-			ele.(*ScaleBox).SWResizeTo(clientX, clientY)
-		case "7":
-			ele.(*ScaleBox).SResizeTo(clientX, clientY)
-		case "8":
-			ele.(*ScaleBox).SEesizeTo(clientX, clientY)
+		ele, ok := ed.doc.Lookup(id[4:])
+		if !ok {
+			return
+		}
+		if r, ok := ele.(handleResizer); ok {
+			x, y := ed.alignPoint(e, clientX, clientY)
+			r.ResizeHandleTo(jsInt(sqr), x, y)
 		}
 	}
 
+	if ed.Rotatable != rotatableNil {
+		ele, _ := ed.doc.Lookup(ed.Rotatable.Attr("id")[4:])
+		// the selection may have changed under the mouse
+		sb, ok := ele.(*ScaleBox)
+		if !ok {
+			return
+		}
+		sb.RotateHandleTo(clientX, clientY, e.ShiftKey)
+	}
+
 	if ed.LineMovable != lineMovableNil {
 		id := ed.LineMovable.Attr("id")
-		ele := m[id[5:]]
+		ele, _ := ed.doc.Lookup(id[5:])
+		sl, ok := ele.(*ScaleLine)
+		if !ok {
+			return
+		}
 		sqr := id[3:4]
-		ele.(*ScaleLine).PointTo(clientX, clientY, jsInt(sqr))
+		x, y := ed.alignPoint(e, clientX, clientY)
+		sl.PointTo(x, y, jsInt(sqr))
 	}
 
 	if ed.Clonable != clonableNil {
-		id := ed.Clonable.Attr("id")
-		ele := m[id]
-		ele.MoveTo(clientX, clientY)
+		if ele, ok := ed.doc.Get(ed.Clonable.Attr("id")); ok {
+			ele.MoveTo(ed.align(e, ele, clientX, clientY))
+		}
+	}
+
+	if m := ed.marquee; m != nil {
+		b := svg.NewBBox(m.x, m.y, clientX, clientY)
+		m.rect.MoveTo(b.X, b.Y)
+		m.rect.ResizeTo(b.Width, b.Height)
 	}
 
 }
 
+// snap puts a point of the canvas on the grid, unless there is none or Alt is held
+func (ed *ControlEditable) snap(e jquery.Event, x, y float64) (float64, float64) {
+	if ed.Grid == nil || e.Get("altKey").Bool() {
+		return x, y
+	}
+	return ed.Grid.Snap(x, ed.X1), ed.Grid.Snap(y, ed.Y1)
+}
+
 func (ed *ControlEditable) startDragging(e jquery.Event) {
-	if ed.Scalable == scalableNill && ed.LineMovable == lineMovableNil {
+	// only the elements of the scope are dragged, not the children of a group or the entered group
+	if _, ok := ed.doc.Lookup(jQuery(e.CurrentTarget).Attr("id")); !ok {
+		return
+	}
+	if ed.Scalable == scalableNill && ed.LineMovable == lineMovableNil && ed.Rotatable == rotatableNil {
 		ed.Movable = Movable{JQuery: jQuery(e.CurrentTarget)}
 		ed.Movable.SetCss("cursor", "move")
+		ed.startGesture(ed.Movable.Attr("id"))
 	}
 
 	ed.Clonable = clonableNil
@@ -239,4 +487,51 @@ func (ed *ControlEditable) stopDraggingResize(e jquery.Event) {
 	if ed.Clonable != clonableNil {
 		ed.Clonable = clonableNil
 	}
+	if ed.Rotatable != rotatableNil {
+		ed.Rotatable = rotatableNil
+	}
+	ed.stopGesture()
+	ed.stopMarquee()
+	ed.showGuides(nil)
+}
+
+// handleResizer is an element resized by dragging its resize squares
+type handleResizer interface {
+	ResizeHandleTo(sqr int, x, y float64)
+}
+
+// startMarquee starts a rubber band on the empty canvas, with Shift or Ctrl it adds to the selection
+func (ed *ControlEditable) startMarquee(e jquery.Event) {
+	x, y := e.Get("offsetX").Float(), e.Get("offsetY").Float()
+	ed.marquee = &marquee{
+		x:        x,
+		y:        y,
+		additive: e.ShiftKey || e.CtrlKey || e.MetaKey,
+		rect:     frameRect(svg.BBox{X: x, Y: y}, "marquee"),
+	}
+	jQuery(e.CurrentTarget).Closest("svg").Append(ed.marquee.rect.JQ())
+}
+
+// stopMarquee selects the elements touched by the rubber band
+func (ed *ControlEditable) stopMarquee() {
+	m := ed.marquee
+	ed.marquee = nil
+	if m == nil {
+		return
+	}
+	jQuery("#" + m.rect.ID).Remove()
+	b := svg.BBox{X: m.rect.X, Y: m.rect.Y, Width: m.rect.Width, Height: m.rect.Height}
+	ids := []string{}
+	for _, ele := range ed.doc.Scope() {
+		if b.Intersects(ele.Svg().BBox()) {
+			ids = append(ids, ele.Id())
+		}
+	}
+	if !m.additive {
+		ed.doc.SelectOnly(ids...)
+		return
+	}
+	for _, id := range ids {
+		ed.doc.Select(id)
+	}
 }
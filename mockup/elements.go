@@ -1,19 +1,22 @@
 package mockup
 
 import (
+	"math"
+	"strconv"
+
 	"github.com/kelwang/gopherjs-mockup/mockup/svg"
 )
 
 var EditablePrefix = "M_"
 
 type Position struct {
-	X float64
-	Y float64
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
 }
 
 type Dimension struct {
-	Width  float64
-	Height float64
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
 }
 
 type MockupElement interface {
@@ -23,6 +26,7 @@ type MockupElement interface {
 	GetWHXY() (float64, float64, float64, float64)
 	MoveTo(x, y float64)
 	ResizeTo(x, y, w, h float64)
+	RotateTo(angle float64)
 	GetBase() BaseElement
 	SetEditable(e svg.Editable)
 }
@@ -30,6 +34,8 @@ type MockupElement interface {
 type BaseElement struct {
 	Position  Position
 	Dimension Dimension
+	// Rotation is the angle in degrees the element is turned clockwise around its center
+	Rotation float64
 }
 
 func (be *BaseElement) GetWHXY() (float64, float64, float64, float64) {
@@ -50,6 +56,30 @@ func (be *BaseElement) ResizeTo(w, h float64) {
 	be.Dimension.Height = h
 }
 
+func (be *BaseElement) RotateTo(angle float64) {
+	be.Rotation = angle
+}
+
+// transformable translates content laid out from the origin to the element's position,
+// and turns it around its center
+func (be *BaseElement) transformable() svg.Transformable {
+	t := svg.Transform{svg.Translate(be.Position.X, be.Position.Y)}
+	if be.Rotation != 0 {
+		t = append(t, svg.Rotate(be.Rotation, be.Dimension.Width/2, be.Dimension.Height/2))
+	}
+	return svg.Transformable{Transform: t}
+}
+
+// transformed is an svg element placed by its transform
+type transformed interface {
+	SetTransform(t svg.Transform)
+}
+
+// place updates the transform of the element's svg after the base changed
+func (be *BaseElement) place(se svg.SvgElement) {
+	se.(transformed).SetTransform(be.transformable().Transform)
+}
+
 func newBaseElement(width, height, x, y float64) BaseElement {
 	return BaseElement{
 		Dimension: Dimension{
@@ -66,7 +96,115 @@ func newBaseElement(width, height, x, y float64) BaseElement {
 type Text struct {
 	Content string
 	Color   string
-	//	Size    int
+	// Size and Family are left to the default font when empty
+	Size      float64
+	Family    string
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Align     Align
+	VAlign    VAlign
+}
+
+// Align is the horizontal alignment of a text in its widget
+type Align int
+
+const (
+	AlignCenter Align = iota
+	AlignLeft
+	AlignRight
+)
+
+// VAlign is the vertical alignment of the lines of a text in its widget
+type VAlign int
+
+const (
+	VAlignMiddle VAlign = iota
+	VAlignTop
+	VAlignBottom
+)
+
+// textPadding keeps text off the frame
+var textPadding = float64(6)
+
+// lineSpacing is the distance between baselines, relative to the font size
+var lineSpacing = float64(1.2)
+
+func (t Text) fontable() svg.Fontable {
+	f := svg.Fontable{
+		FontFamily: t.Family,
+		FontSize:   t.Size,
+		Bold:       t.Bold,
+		Italic:     t.Italic,
+		Underline:  t.Underline,
+		TextAnchor: svg.MIDDLE,
+	}
+	switch t.Align {
+	case AlignLeft:
+		f.TextAnchor = svg.START
+	case AlignRight:
+		f.TextAnchor = svg.END
+	}
+	return f
+}
+
+// layout breaks the text into lines fitting a w x h frame, placed relative to the top left corner of the frame.
+// Lines are wrapped to the width of the frame, the last line that fits ends with an ellipsis when the text overflows.
+// Horizontally lines are anchored by the alignment, the block of lines is aligned vertically.
+func (t Text) layout(w, h float64) []svg.TSpan {
+	f := t.fontable().Font()
+	m := svg.MeasureText(t.Content, f)
+	width := math.Max(w-2*textPadding, 0)
+	lines := svg.WrapText(t.Content, f, width)
+
+	spacing := lineSpacing * f.Size
+	fit := int(math.Floor((h-2*textPadding-m.Height())/spacing)) + 1
+	if fit < 1 {
+		fit = 1
+	}
+	if len(lines) > fit {
+		lines = lines[:fit]
+		lines[fit-1] = svg.Ellipsize(lines[fit-1]+svg.Ellipsis, f, width)
+	}
+
+	height := float64(len(lines)-1)*spacing + m.Height()
+	y := (h-height)/2 + m.Ascent
+	switch t.VAlign {
+	case VAlignTop:
+		y = textPadding + m.Ascent
+	case VAlignBottom:
+		y = h - textPadding - height + m.Ascent
+	}
+	x := w / 2
+	switch t.Align {
+	case AlignLeft:
+		x = textPadding
+	case AlignRight:
+		x = w - textPadding
+	}
+
+	spans := make([]svg.TSpan, len(lines))
+	for k, line := range lines {
+		spans[k] = svg.NewTSpan(line, x, y+float64(k)*spacing)
+	}
+	return spans
+}
+
+// TextElement is a widget showing a text
+type TextElement interface {
+	MockupElement
+	GetText() Text
+	SetText(t Text)
+}
+
+func (t *Text) GetText() Text {
+	return *t
+}
+
+// setFramedText lays out the content of t again in a widget drawn by resizeFramed
+func setFramedText(ele svg.SvgElement, be *BaseElement, t Text) {
+	w, h, _, _ := be.GetWHXY()
+	ele.(*svg.Group).Content[1].(*svg.Text).SetSpans(t.layout(w, h))
 }
 
 type Thickness int
@@ -89,6 +227,21 @@ func (thickness Thickness) Float64() float64 {
 	return 0.5 * float64(thickness)
 }
 
+var thicknessString = []string{"none", "very thin", "thin", "medium", "thick", "very thick"}
+
+func (thickness Thickness) String() string {
+	if thickness < None || thickness > VeryThick {
+		return strconv.Itoa(int(thickness))
+	}
+	return thicknessString[thickness]
+}
+
+// Fill is the background of a shape, Opacity goes from 0, transparent, to 1
+type Fill struct {
+	Color   string
+	Opacity float64
+}
+
 type idable struct {
 	id string
 }
@@ -114,6 +267,7 @@ type textBox struct {
 	BaseElement
 	Text
 	Stroke
+	Fill
 	editable
 }
 
@@ -133,63 +287,75 @@ func NewTextBox(w, h, x, y float64, content string, id string, e svg.Editable) *
 			Thickness: Medium,
 			Color:     DARKGREY,
 		},
+		Fill:     Fill{Color: WHITE, Opacity: 1},
 		idable:   idable{id: id},
 		editable: editable{Editable: e},
 	}
 }
 
 func (ele *textBox) Svg() svg.SvgElement {
+	w, h, _, _ := ele.GetWHXY()
 	return &svg.Group{
 		Editable: ele.editable.Editable,
-		Idable: svg.Idable{
+		IDAble: svg.IDAble{
 			ID: ele.id,
 		},
+		Transformable: ele.BaseElement.transformable(),
+		// the shapes inside set their own fill, the group doesn't make them transparent
+		Fillable: svg.NewFillable("", 1),
 		Content: []svg.SvgElement{
 			&svg.Rect{
-				Width:    ele.BaseElement.Dimension.Width,
-				Height:   ele.BaseElement.Dimension.Height,
-				X:        ele.BaseElement.Position.X,
-				Y:        ele.BaseElement.Position.Y,
-				Fillable: svg.NewFillable(WHITE, 1),
+				Width:    w,
+				Height:   h,
+				Fillable: svg.NewFillable(ele.Fill.Color, ele.Fill.Opacity),
 				Strokeable: svg.Strokeable{
 					Stroke:      ele.Stroke.Color,
 					StrokeWidth: ele.Stroke.Thickness.Float64(),
 				},
-				Idable: svg.Idable{ID: ele.idable.id + "_outter"},
+				IDAble: svg.IDAble{ID: ele.idable.id + "_outter"},
 			},
 			&svg.Text{
-				Content: ele.Text.Content,
-				X:       ele.BaseElement.Position.X + (ele.BaseElement.Dimension.Width-float64(7*len(ele.Text.Content)))/2,
-				Y:       ele.BaseElement.Position.Y + (ele.BaseElement.Dimension.Height+7)/2,
+				Spans:    ele.Text.layout(w, h),
+				Fontable: ele.Text.fontable(),
 				Strokeable: svg.Strokeable{
 					Stroke:      ele.Text.Color,
 					StrokeWidth: ele.Stroke.Thickness.Float64(),
 				},
-				Idable: svg.Idable{ID: ele.idable.id + "_inner"},
+				IDAble: svg.IDAble{ID: ele.idable.id + "_inner"},
 			},
 		},
 	}
 }
 
 func (ele *textBox) MoveTo(x, y float64) {
-	w, h, _, _ := ele.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-	content[0].MoveTo(x, y)
-	x1 := x + w/2 - float64(7*len(ele.Text.Content))/2
-	y1 := y + (h+7)/2
-	content[1].MoveTo(x1, y1)
 	ele.BaseElement.MoveTo(x, y)
+	ele.Svg().MoveTo(x, y)
 }
 
 func (ele *textBox) ResizeTo(x, y, w, h float64) {
-	content := ele.Svg().(*svg.Group).Content
-	content[0].ResizeTo(w, h)
-	ele.BaseElement.ResizeTo(w, h)
-	content[0].MoveTo(x, y)
 	ele.BaseElement.MoveTo(x, y)
-	x1 := x + w/2 - float64(7*len(ele.Text.Content))/2
-	y1 := y + (h+7)/2
-	content[1].MoveTo(x1, y1)
+	ele.BaseElement.ResizeTo(w, h)
+	resizeFramed(ele.Svg(), &ele.BaseElement, ele.Text)
+}
+
+func (ele *textBox) RotateTo(angle float64) {
+	ele.BaseElement.RotateTo(angle)
+	ele.BaseElement.place(ele.Svg())
+}
+
+func (ele *textBox) SetText(t Text) {
+	ele.Text = t
+	setFramedText(ele.Svg(), &ele.BaseElement, ele.Text)
+}
+
+// resizeFramed updates a widget drawn as a group of a frame and a text laid out in it.
+// The group is placed by the transform of the base, its children are laid out from its origin.
+func resizeFramed(ele svg.SvgElement, be *BaseElement, t Text) {
+	w, h, _, _ := be.GetWHXY()
+	g := ele.(*svg.Group)
+	be.place(g)
+	g.Content[0].ResizeTo(w, h)
+	g.Content[1].(*svg.Text).SetSpans(t.layout(w, h))
 }
 
 func min(a, b float64) float64 {
@@ -204,6 +370,7 @@ type button struct {
 	BaseElement
 	Text
 	Stroke
+	Fill
 	editable
 }
 
@@ -220,41 +387,42 @@ func NewButton(w, h, x, y float64, content string, id string, e svg.Editable) *b
 			Thickness: Medium,
 			Color:     DARKGREY,
 		},
+		Fill: Fill{Color: WHITE, Opacity: 1},
 	}
 }
 
 func (ele *button) Svg() svg.SvgElement {
+	w, h, _, _ := ele.GetWHXY()
 	return &svg.Group{
-		Idable: svg.Idable{
+		IDAble: svg.IDAble{
 			ID: ele.idable.id,
 		},
-		Editable: ele.editable.Editable,
+		Editable:      ele.editable.Editable,
+		Transformable: ele.BaseElement.transformable(),
+		Fillable:      svg.NewFillable("", 1),
 		Content: []svg.SvgElement{
 			&svg.Rect{
-				Width:    ele.BaseElement.Dimension.Width,
-				Height:   ele.BaseElement.Dimension.Height,
-				X:        ele.BaseElement.Position.X,
-				Y:        ele.BaseElement.Position.Y,
-				RX:       min(ele.BaseElement.Dimension.Width, ele.BaseElement.Dimension.Height) / 4,
-				RY:       min(ele.BaseElement.Dimension.Width, ele.BaseElement.Dimension.Height) / 4,
-				Fillable: svg.NewFillable(WHITE, 1),
+				Width:    w,
+				Height:   h,
+				RX:       min(w, h) / 4,
+				RY:       min(w, h) / 4,
+				Fillable: svg.NewFillable(ele.Fill.Color, ele.Fill.Opacity),
 				Strokeable: svg.Strokeable{
 					Stroke:      ele.Stroke.Color,
 					StrokeWidth: ele.Stroke.Thickness.Float64(),
 				},
-				Idable: svg.Idable{
+				IDAble: svg.IDAble{
 					ID: ele.idable.id + "_outer",
 				},
 			},
 			&svg.Text{
-				Content: ele.Text.Content,
-				X:       ele.BaseElement.Position.X + (ele.BaseElement.Dimension.Width-float64(7*len(ele.Text.Content)))/2,
-				Y:       ele.BaseElement.Position.Y + (ele.BaseElement.Dimension.Height+7)/2,
+				Spans:    ele.Text.layout(w, h),
+				Fontable: ele.Text.fontable(),
 				Strokeable: svg.Strokeable{
 					Stroke:      ele.Text.Color,
 					StrokeWidth: ele.Stroke.Thickness.Float64(),
 				},
-				Idable: svg.Idable{
+				IDAble: svg.IDAble{
 					ID: ele.idable.id + "_inner",
 				},
 			},
@@ -263,30 +431,31 @@ func (ele *button) Svg() svg.SvgElement {
 }
 
 func (ele *button) MoveTo(x, y float64) {
-	w, h, _, _ := ele.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-	content[0].MoveTo(x, y)
-	x1 := x + w/2 - float64(7*len(ele.Text.Content))/2
-	y1 := y + (h+7)/2
-	content[1].MoveTo(x1, y1)
 	ele.BaseElement.MoveTo(x, y)
+	ele.Svg().MoveTo(x, y)
 }
 
 func (ele *button) ResizeTo(x, y, w, h float64) {
-	content := ele.Svg().(*svg.Group).Content
-	content[0].ResizeTo(w, h)
-	ele.BaseElement.ResizeTo(w, h)
-	content[0].MoveTo(x, y)
 	ele.BaseElement.MoveTo(x, y)
-	x1 := x + w/2 - float64(7*len(ele.Text.Content))/2
-	y1 := y + (h+7)/2
-	content[1].MoveTo(x1, y1)
+	ele.BaseElement.ResizeTo(w, h)
+	resizeFramed(ele.Svg(), &ele.BaseElement, ele.Text)
+}
+
+func (ele *button) RotateTo(angle float64) {
+	ele.BaseElement.RotateTo(angle)
+	ele.BaseElement.place(ele.Svg())
+}
+
+func (ele *button) SetText(t Text) {
+	ele.Text = t
+	setFramedText(ele.Svg(), &ele.BaseElement, ele.Text)
 }
 
 type box struct {
 	idable
 	BaseElement
 	Stroke
+	Fill
 	editable
 }
 
@@ -299,40 +468,46 @@ func NewBox(w, h, x, y float64, id string, e svg.Editable) *box {
 			Thickness: Medium,
 			Color:     DARKGREY,
 		},
+		Fill: Fill{Color: WHITE, Opacity: 1},
 	}
 }
 
 func (ele *box) Svg() svg.SvgElement {
+	w, h, _, _ := ele.GetWHXY()
 	return &svg.Rect{
-		Width:    ele.BaseElement.Dimension.Width,
-		Height:   ele.BaseElement.Dimension.Height,
-		X:        ele.BaseElement.Position.X,
-		Y:        ele.BaseElement.Position.Y,
-		RX:       min(ele.BaseElement.Dimension.Width, ele.BaseElement.Dimension.Height) / 8,
-		RY:       min(ele.BaseElement.Dimension.Width, ele.BaseElement.Dimension.Height) / 8,
-		Fillable: svg.NewFillable(WHITE, 1),
+		Width:         w,
+		Height:        h,
+		RX:            min(w, h) / 8,
+		RY:            min(w, h) / 8,
+		Transformable: ele.BaseElement.transformable(),
+		Fillable:      svg.NewFillable(ele.Fill.Color, ele.Fill.Opacity),
 		Strokeable: svg.Strokeable{
 			Stroke:      ele.Stroke.Color,
 			StrokeWidth: ele.Stroke.Thickness.Float64(),
 		},
 		Editable: ele.editable.Editable,
-		Idable: svg.Idable{
+		IDAble: svg.IDAble{
 			ID: ele.id,
 		},
 	}
 }
 
 func (ele *box) MoveTo(x, y float64) {
-	ele.Svg().MoveTo(x, y)
 	ele.BaseElement.MoveTo(x, y)
+	ele.BaseElement.place(ele.Svg())
 }
 
 func (ele *box) ResizeTo(x, y, w, h float64) {
-	ele.Svg().ResizeTo(w, h)
-	ele.BaseElement.ResizeTo(w, h)
-	ele.Svg().MoveTo(x, y)
 	ele.BaseElement.MoveTo(x, y)
+	ele.BaseElement.ResizeTo(w, h)
+	r := ele.Svg()
+	r.ResizeTo(w, h)
+	ele.BaseElement.place(r)
+}
 
+func (ele *box) RotateTo(angle float64) {
+	ele.BaseElement.RotateTo(angle)
+	ele.BaseElement.place(ele.Svg())
 }
 
 type label struct {
@@ -340,6 +515,7 @@ type label struct {
 	BaseElement
 	Text
 	Stroke
+	Fill
 	editable
 }
 
@@ -355,59 +531,60 @@ func NewLabel(w, h, x, y float64, content string, id string, e svg.Editable) *la
 			Thickness: Medium,
 			Color:     DARKGREY,
 		},
+		Fill:     Fill{Color: WHITE, Opacity: 0},
 		editable: editable{Editable: e},
 	}
 }
 
 func (ele *label) Svg() svg.SvgElement {
+	w, h, _, _ := ele.GetWHXY()
 	return &svg.Group{
 		Editable: ele.editable.Editable,
-		Idable: svg.Idable{
+		IDAble: svg.IDAble{
 			ID: ele.id,
 		},
+		Transformable: ele.BaseElement.transformable(),
+		Fillable:      svg.NewFillable("", 1),
 		Content: []svg.SvgElement{
 			&svg.Rect{
-				Width:    ele.BaseElement.Dimension.Width,
-				Height:   ele.BaseElement.Dimension.Height,
-				X:        ele.BaseElement.Position.X,
-				Y:        ele.BaseElement.Position.Y,
-				Fillable: svg.NewFillable(WHITE, 0),
-				Idable:   svg.Idable{ID: ele.idable.id + "_outter"},
+				Width:    w,
+				Height:   h,
+				Fillable: svg.NewFillable(ele.Fill.Color, ele.Fill.Opacity),
+				IDAble:   svg.IDAble{ID: ele.idable.id + "_outter"},
 			},
 			&svg.Text{
-				Content: ele.Text.Content,
-				X:       ele.BaseElement.Position.X + (ele.BaseElement.Dimension.Width-float64(7*len(ele.Text.Content)))/2,
-				Y:       ele.BaseElement.Position.Y + (ele.BaseElement.Dimension.Height+7)/2,
+				Spans:    ele.Text.layout(w, h),
+				Fontable: ele.Text.fontable(),
 				Strokeable: svg.Strokeable{
 					Stroke:      ele.Text.Color,
 					StrokeWidth: ele.Stroke.Thickness.Float64(),
 				},
 				Editable: svg.EDITABLE,
-				Idable:   svg.Idable{ID: ele.idable.id + "_inner"},
+				IDAble:   svg.IDAble{ID: ele.idable.id + "_inner"},
 			},
 		},
 	}
 }
 
 func (ele *label) MoveTo(x, y float64) {
-	w, h, _, _ := ele.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-	content[0].MoveTo(x, y)
-	x1 := x + w/2 - float64(7*len(ele.Text.Content))/2
-	y1 := y + (h+7)/2
-	content[1].MoveTo(x1, y1)
 	ele.BaseElement.MoveTo(x, y)
+	ele.Svg().MoveTo(x, y)
 }
 
 func (ele *label) ResizeTo(x, y, w, h float64) {
-	content := ele.Svg().(*svg.Group).Content
-	content[0].ResizeTo(w, h)
-	ele.BaseElement.ResizeTo(w, h)
-	content[0].MoveTo(x, y)
 	ele.BaseElement.MoveTo(x, y)
-	x1 := x + w/2 - float64(7*len(ele.Text.Content))/2
-	y1 := y + (h+7)/2
-	content[1].MoveTo(x1, y1)
+	ele.BaseElement.ResizeTo(w, h)
+	resizeFramed(ele.Svg(), &ele.BaseElement, ele.Text)
+}
+
+func (ele *label) RotateTo(angle float64) {
+	ele.BaseElement.RotateTo(angle)
+	ele.BaseElement.place(ele.Svg())
+}
+
+func (ele *label) SetText(t Text) {
+	ele.Text = t
+	setFramedText(ele.Svg(), &ele.BaseElement, ele.Text)
 }
 
 type line struct {
@@ -436,11 +613,11 @@ func (ele *line) Svg() svg.SvgElement {
 		X2: ele.BaseElement.Position.X + ele.BaseElement.Dimension.Width,
 		Y2: ele.BaseElement.Position.Y + ele.BaseElement.Dimension.Height,
 		Strokeable: svg.Strokeable{
-			Stroke:      DARKGREY,
+			Stroke:      ele.Stroke.Color,
 			StrokeWidth: ele.Stroke.Thickness.Float64(),
 		},
 		Editable: svg.LINABLE,
-		Idable: svg.Idable{
+		IDAble: svg.IDAble{
 			ID: ele.id,
 		},
 	}
@@ -452,7 +629,15 @@ func (ele *line) MoveTo(x, y float64) {
 }
 
 func (ele *line) ResizeTo(x, y, w, h float64) {
+	ele.BaseElement.MoveTo(x, y)
+	ele.BaseElement.ResizeTo(w, h)
+	l := ele.Svg().(*svg.Line)
+	l.PointTo(l.X1, l.Y1, 1)
+	l.PointTo(l.X2, l.Y2, 2)
+}
 
+// RotateTo does nothing, a line is turned by moving its end points
+func (ele *line) RotateTo(angle float64) {
 }
 
 func (ele *line) PointTo(x, y float64, pt int) {
@@ -487,173 +672,183 @@ func (ele *ScaleBox) SetId(id string) {
 }
 
 func (ele *ScaleBox) MoveTo(x, y float64) {
-	content := ele.Svg().(*svg.Group).Content
-	w, h, _, _ := ele.MockupElement.GetWHXY()
-	content[1].MoveTo(x-square_height/2, y-square_height/2)
-	content[2].MoveTo(x+w/2-square_height/2, y-square_height/2)
-	content[3].MoveTo(x-square_height/2, y+h/2-square_height/2)
-	content[4].MoveTo(x-square_height/2, y+h-square_height/2)
-	content[5].MoveTo(x+w-square_height/2, y-square_height/2)
-	content[6].MoveTo(x+w/2-square_height/2, y+h-square_height/2)
-	content[7].MoveTo(x+w-square_height/2, y+h/2-square_height/2)
-	content[8].MoveTo(x+w-square_height/2, y+h-square_height/2)
-
 	ele.MockupElement.MoveTo(x, y)
+	ele.layout()
 }
 
-var stroke_width = float64(2)
-var square_height = float64(8)
-
-func (ele *ScaleBox) Svg() svg.SvgElement {
-
-	w, h, x, y := ele.MockupElement.GetWHXY()
-	return &svg.Group{
-		Content: []svg.SvgElement{
-			ele.MockupElement.Svg(),
-			scaleboxRect(x-square_height/2, y-square_height/2, stroke_width, square_height, "sq1_"+ele.idable.id, svg.NWSE_RESIZABLE),
-			scaleboxRect(x+w/2-square_height/2, y-square_height/2, stroke_width, square_height, "sq2_"+ele.idable.id, svg.NS_RESIZABLE),
-			scaleboxRect(x+w-square_height/2, y-square_height/2, stroke_width, square_height, "sq3_"+ele.idable.id, svg.NESW_RESIZABLE),
-			scaleboxRect(x-square_height/2, y+h/2-square_height/2, stroke_width, square_height, "sq4_"+ele.idable.id, svg.EW_RESIZABLE),
-			scaleboxRect(x+w-square_height/2, y+h/2-square_height/2, stroke_width, square_height, "sq5_"+ele.idable.id, svg.EW_RESIZABLE),
-			scaleboxRect(x-square_height/2, y+h-square_height/2, stroke_width, square_height, "sq6_"+ele.idable.id, svg.NESW_RESIZABLE),
-			scaleboxRect(x+w/2-square_height/2, y+h-square_height/2, stroke_width, square_height, "sq7_"+ele.idable.id, svg.NS_RESIZABLE),
-			scaleboxRect(x+w-square_height/2, y+h-square_height/2, stroke_width, square_height, "sq8_"+ele.idable.id, svg.NWSE_RESIZABLE),
-		},
-		Editable: svg.DRAGGABLE,
-		Fillable: svg.NewFillable(WHITE, 1),
-		Idable: svg.Idable{
-			ID: ele.idable.id,
-		},
-	}
-
-}
-
-func (ele *ScaleBox) NWResizeTo(x, y float64) {
-	w0, h0, x0, y0 := ele.MockupElement.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-	w := w0 + x0 - x
-	h := h0 + y0 - y
-
-	content[1].MoveTo(x-square_height/2, y-square_height/2)
-	content[2].MoveTo((x+content[8].(*svg.Rect).X)/2-square_height/2, y-square_height/2)
-	content[3].MoveTo(content[8].(*svg.Rect).X, y-square_height/2)
-	content[4].MoveTo(x-square_height/2, (y+content[8].(*svg.Rect).Y)/2)
-	content[5].MoveTo(content[8].(*svg.Rect).X, (y+content[8].(*svg.Rect).Y)/2)
-	content[6].MoveTo(x-square_height/2, content[8].(*svg.Rect).Y)
-	content[7].MoveTo((x+content[8].(*svg.Rect).X)/2-square_height/2, content[8].(*svg.Rect).Y)
-
+func (ele *ScaleBox) ResizeTo(x, y, w, h float64) {
 	ele.MockupElement.ResizeTo(x, y, w, h)
+	ele.layout()
 }
 
-func (ele *ScaleBox) NResizeTo(x, y float64) {
-	w0, h0, x0, y0 := ele.MockupElement.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-
-	h := h0 + y0 - y
-
-	content[1].MoveTo(x0-square_height/2, y-square_height/2)
-	content[2].MoveTo(content[2].(*svg.Rect).X-square_height/2, y-square_height/2)
-	content[3].MoveTo(content[3].(*svg.Rect).X-square_height/2, y-square_height/2)
-	content[4].MoveTo(x0-square_height/2, (y+content[8].(*svg.Rect).Y)/2)
-	content[5].MoveTo(content[5].(*svg.Rect).X, (y+content[8].(*svg.Rect).Y)/2)
-
-	ele.MockupElement.ResizeTo(x0, y, w0, h)
+func (ele *ScaleBox) RotateTo(angle float64) {
+	ele.MockupElement.RotateTo(angle)
+	ele.layout()
 }
 
-func (ele *ScaleBox) NEResizeTo(x, y float64) {
-	_, h0, x0, y0 := ele.MockupElement.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-	w := x - x0
-	h := h0 + y0 - y
-
-	content[1].MoveTo(content[6].(*svg.Rect).X, y-square_height/2)
-	content[2].MoveTo((x+content[6].(*svg.Rect).X)/2, y-square_height/2)
-	content[3].MoveTo(x-square_height/2, y-square_height/2)
-	content[4].MoveTo(content[6].(*svg.Rect).X, (y+content[6].(*svg.Rect).Y)/2)
-	content[5].MoveTo(x-square_height/2, (y+content[6].(*svg.Rect).Y)/2)
-	content[7].MoveTo((x+content[6].(*svg.Rect).X)/2, content[6].(*svg.Rect).Y)
-	content[8].MoveTo(x-square_height/2, content[6].(*svg.Rect).Y)
+var stroke_width = float64(2)
+var square_height = float64(8)
 
-	ele.MockupElement.ResizeTo(x0, y, w, h)
+// rotate_offset is how far above the frame the rotation handle sits
+var rotate_offset = float64(24)
+
+// rotate_snap is the step in degrees rotations snap to while Shift is held
+var rotate_snap = float64(15)
+
+// handle is a control of the ScaleBox, its id is the prefix followed by the ScaleBox id
+type handle struct {
+	prefix   string
+	x        float64
+	y        float64
+	editable svg.Editable
+}
+
+// handles lays out the controls around a w x h frame, in the coordinates of the frame
+func handles(w, h float64) []handle {
+	return []handle{
+		{"sq1_", 0, 0, svg.NWSE_RESIZABLE},
+		{"sq2_", w / 2, 0, svg.NS_RESIZABLE},
+		{"sq3_", w, 0, svg.NESW_RESIZABLE},
+		{"sq4_", 0, h / 2, svg.EW_RESIZABLE},
+		{"sq5_", w, h / 2, svg.EW_RESIZABLE},
+		{"sq6_", 0, h, svg.NESW_RESIZABLE},
+		{"sq7_", w / 2, h, svg.NS_RESIZABLE},
+		{"sq8_", w, h, svg.NWSE_RESIZABLE},
+		{"rot_", w / 2, -rotate_offset, svg.ROTATABLE},
+	}
 }
 
-func (ele *ScaleBox) WResizeTo(x, y float64) {
-	w0, h0, x0, y0 := ele.MockupElement.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-
-	w := w0 + x0 - x
-
-	content[1].MoveTo(x-square_height/2, content[1].(*svg.Rect).Y)
-	content[2].MoveTo((x+content[3].(*svg.Rect).X)/2, content[3].(*svg.Rect).Y)
-	content[4].MoveTo(x-square_height/2, content[4].(*svg.Rect).Y)
-	content[6].MoveTo(x-square_height/2, content[6].(*svg.Rect).Y)
-	content[7].MoveTo((x+content[3].(*svg.Rect).X)/2, content[7].(*svg.Rect).Y)
-
-	ele.MockupElement.ResizeTo(x, y0, w, h0)
+// handleEdges are the edges each resize square moves: left, top, right and bottom
+var handleEdges = [9][4]bool{
+	1: {true, true, false, false},
+	2: {false, true, false, false},
+	3: {false, true, true, false},
+	4: {true, false, false, false},
+	5: {false, false, true, false},
+	6: {true, false, false, true},
+	7: {false, false, false, true},
+	8: {false, false, true, true},
 }
 
-func (ele *ScaleBox) EResizeTo(x, y float64) {
-	_, h0, x0, y0 := ele.MockupElement.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-
-	w := x - x0
-
-	content[3].MoveTo(x-square_height/2, content[3].(*svg.Rect).Y)
-	content[2].MoveTo((x0+content[3].(*svg.Rect).X)/2, content[3].(*svg.Rect).Y)
-	content[5].MoveTo(x-square_height/2, content[5].(*svg.Rect).Y)
-	content[7].MoveTo((x0+content[3].(*svg.Rect).X)/2, content[7].(*svg.Rect).Y)
-	content[8].MoveTo(x-square_height/2, content[8].(*svg.Rect).Y)
-
-	ele.MockupElement.ResizeTo(x0, y0, w, h0)
+// dragEdges moves the edges of a frame that the resize square sqr moves to p, an edge doesn't cross the opposite one
+func dragEdges(sqr int, left, top, right, bottom float64, p svg.Point) (float64, float64, float64, float64) {
+	edges := handleEdges[sqr]
+	if edges[0] {
+		left = math.Min(p.X(), right)
+	}
+	if edges[1] {
+		top = math.Min(p.Y(), bottom)
+	}
+	if edges[2] {
+		right = math.Max(p.X(), left)
+	}
+	if edges[3] {
+		bottom = math.Max(p.Y(), top)
+	}
+	return left, top, right, bottom
 }
 
-func (ele *ScaleBox) SWResizeTo(x, y float64) {
-	w0, _, x0, y0 := ele.MockupElement.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-	w := w0 + x0 - x
-	h := y - y0
-
-	content[1].MoveTo(x-square_height/2, y0)
-	content[2].MoveTo((x+content[3].(*svg.Rect).X)/2-square_height/2, y0)
-	content[4].MoveTo(x-square_height/2, (y+y0)/2)
-	content[5].MoveTo(content[3].(*svg.Rect).X, (y+content[3].(*svg.Rect).Y)/2)
-	content[6].MoveTo(x-square_height/2, y-square_height/2)
-	content[7].MoveTo((x+content[3].(*svg.Rect).X)/2, y-square_height/2)
-	content[8].MoveTo(content[3].(*svg.Rect).X, y-square_height/2)
-
-	ele.MockupElement.ResizeTo(x, y-h, w, h)
+// rotatable reports whether the wrapped element turns, the children of a group are turned one by one
+func (ele *ScaleBox) rotatable() bool {
+	_, ok := ele.MockupElement.(*group)
+	return !ok
 }
 
-func (ele *ScaleBox) SResizeTo(x, y float64) {
-	w0, _, x0, y0 := ele.MockupElement.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-	h := y - y0
-
-	content[4].MoveTo(x0-square_height/2, (y+content[3].(*svg.Rect).Y)/2)
-	content[5].MoveTo(content[3].(*svg.Rect).X, (y+content[3].(*svg.Rect).Y)/2)
-	content[6].MoveTo(x0-square_height/2, y-square_height/2)
-	content[7].MoveTo(content[2].(*svg.Rect).X, y-square_height/2)
-	content[8].MoveTo(content[3].(*svg.Rect).X, y-square_height/2)
-
-	ele.MockupElement.ResizeTo(x0, y0, w0, h)
+// Svg draws the element with its controls, which turn along with the element.
+// An element that doesn't turn has no rotation handle.
+func (ele *ScaleBox) Svg() svg.SvgElement {
+	base := ele.MockupElement.GetBase()
+	w, h, _, _ := base.GetWHXY()
+	controls := []svg.SvgElement{}
+	if ele.rotatable() {
+		controls = append(controls, &svg.Line{
+			X1: w / 2,
+			Y1: -rotate_offset,
+			X2: w / 2,
+			Y2: 0,
+			Strokeable: svg.Strokeable{
+				Stroke:      DARKGREY,
+				StrokeWidth: stroke_width / 2,
+			},
+			IDAble: svg.IDAble{ID: "stm_" + ele.idable.id},
+		})
+	}
+	for _, hd := range handles(w, h) {
+		if hd.editable == svg.ROTATABLE {
+			if ele.rotatable() {
+				controls = append(controls, rotateCircle(hd.x, hd.y, hd.prefix+ele.idable.id))
+			}
+			continue
+		}
+		controls = append(controls, scaleboxRect(hd.x-square_height/2, hd.y-square_height/2, stroke_width, square_height, hd.prefix+ele.idable.id, hd.editable))
+	}
+	return &svg.Group{
+		Content: []svg.SvgElement{
+			ele.MockupElement.Svg(),
+			&svg.Group{
+				Content:       controls,
+				Transformable: base.transformable(),
+				// the handles are filled white, the group doesn't make them transparent
+				Fillable: svg.NewFillable("", 1),
+				IDAble:   svg.IDAble{ID: "ctl_" + ele.idable.id},
+			},
+		},
+		Editable: svg.DRAGGABLE,
+		Fillable: svg.NewFillable(WHITE, 1),
+		IDAble: svg.IDAble{
+			ID: ele.idable.id,
+		},
+	}
 }
 
-func (ele *ScaleBox) SEesizeTo(x, y float64) {
-	_, _, x0, y0 := ele.MockupElement.GetWHXY()
-	content := ele.Svg().(*svg.Group).Content
-	w := x - x0
-	h := y - y0
-
-	content[2].MoveTo((x+x0)/2, y0-square_height/2)
-	content[3].MoveTo(x-square_height/2, content[3].(*svg.Rect).Y)
-	content[4].MoveTo(x0-square_height/2, (y+content[3].(*svg.Rect).Y)/2)
-	content[5].MoveTo(content[3].(*svg.Rect).X, (y+content[3].(*svg.Rect).Y)/2)
-	content[6].MoveTo(x0-square_height/2, y-square_height/2)
-	content[7].MoveTo((x+x0-square_height/2)/2, y-square_height/2)
-	content[8].MoveTo(content[3].(*svg.Rect).X, y-square_height/2)
-
-	ele.MockupElement.ResizeTo(x0, y0, w, h)
+// layout updates the controls on the page to the geometry of the element
+func (ele *ScaleBox) layout() {
+	base := ele.MockupElement.GetBase()
+	w, h, _, _ := base.GetWHXY()
+	controls := ele.Svg().(*svg.Group).Content[1].(*svg.Group)
+	base.place(controls)
+	content := controls.Content
+	if ele.rotatable() {
+		content[0].MoveTo(w/2, -rotate_offset)
+		content = content[1:]
+	}
+	k := 0
+	for _, hd := range handles(w, h) {
+		if hd.editable == svg.ROTATABLE && !ele.rotatable() {
+			continue
+		}
+		content[k].MoveTo(hd.x-square_height/2, hd.y-square_height/2)
+		k++
+	}
+}
 
+// ResizeHandleTo drags the resize square sqr to x, y.
+// The square moves in the rotated frame of the element while the opposite corner or edge stays in place.
+func (ele *ScaleBox) ResizeHandleTo(sqr int, x, y float64) {
+	if sqr < 1 || sqr > 8 {
+		return
+	}
+	base := ele.MockupElement.GetBase()
+	w0, h0, x0, y0 := base.GetWHXY()
+	rot := svg.Rotate(base.Rotation, x0+w0/2, y0+h0/2).Matrix()
+	inv, _ := rot.Invert()
+	p := inv.Apply(svg.NewPoint(x, y))
+
+	left, top, right, bottom := dragEdges(sqr, x0, y0, x0+w0, y0+h0, p)
+	w, h := right-left, bottom-top
+	// the resized frame turns around its own center, which the old rotation puts here
+	c := rot.Apply(svg.NewPoint(left+w/2, top+h/2))
+	ele.ResizeTo(c.X()-w/2, c.Y()-h/2, w, h)
+}
+
+// RotateHandleTo turns the element so the rotation handle points to x, y, snapping to rotate_snap steps
+func (ele *ScaleBox) RotateHandleTo(x, y float64, snap bool) {
+	w, h, x0, y0 := ele.MockupElement.GetWHXY()
+	angle := math.Atan2(y-(y0+h/2), x-(x0+w/2))*180/math.Pi + 90
+	if snap {
+		angle = math.Floor(angle/rotate_snap+0.5) * rotate_snap
+	}
+	ele.RotateTo(math.Mod(angle+360, 360))
 }
 
 func scaleboxRect(x, y, stroke_width, square_height float64, id string, ed svg.Editable) *svg.Rect {
@@ -667,13 +862,30 @@ func scaleboxRect(x, y, stroke_width, square_height float64, id string, ed svg.E
 			Stroke:      DARKGREY,
 			StrokeWidth: stroke_width,
 		},
-		Idable: svg.Idable{
+		IDAble: svg.IDAble{
 			ID: id,
 		},
 		Editable: ed,
 	}
 }
 
+func rotateCircle(cx, cy float64, id string) *svg.Circle {
+	return &svg.Circle{
+		X:        cx,
+		Y:        cy,
+		R:        square_height / 2,
+		Fillable: svg.NewFillable(WHITE, 1),
+		Strokeable: svg.Strokeable{
+			Stroke:      DARKGREY,
+			StrokeWidth: stroke_width,
+		},
+		IDAble: svg.IDAble{
+			ID: id,
+		},
+		Editable: svg.ROTATABLE,
+	}
+}
+
 type ScaleLine struct {
 	idable
 	*line
@@ -701,7 +913,7 @@ func (ele *ScaleLine) Svg() svg.SvgElement {
 		},
 		Editable: svg.DRAGGABLE,
 		Fillable: svg.NewFillable(WHITE, 1),
-		Idable: svg.Idable{
+		IDAble: svg.IDAble{
 			ID: ele.idable.id,
 		},
 	}
@@ -724,8 +936,7 @@ type CloneBox struct {
 	MockupElement
 }
 
-func newCloneBox(ele MockupElement, id string) *CloneBox {
-	ele.SetId(id)
+func newCloneBox(ele MockupElement) *CloneBox {
 	ele.SetEditable(svg.EDITABLE | svg.DRAGGABLE)
 	return &CloneBox{
 		MockupElement: ele,
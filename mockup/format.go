@@ -0,0 +1,343 @@
+package mockup
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// FormatVersion is the version of the JSON document written by Marshal
+const FormatVersion = 1
+
+// element type names used in the JSON document
+const (
+	TextBoxType = "textBox"
+	ButtonType  = "button"
+	BoxType     = "box"
+	LabelType   = "label"
+	LineType    = "line"
+	GroupType   = "group"
+)
+
+type documentRecord struct {
+	Version int `json:"version"`
+	// Elements are in z-order, bottom first, as are the children of a group
+	Elements []elementRecord `json:"elements"`
+}
+
+type elementRecord struct {
+	Type      string       `json:"type"`
+	ID        string       `json:"id"`
+	Position  Position     `json:"position"`
+	Dimension Dimension    `json:"dimension"`
+	Rotation  float64      `json:"rotation,omitempty"`
+	Text      *textRecord  `json:"text,omitempty"`
+	Stroke    strokeRecord `json:"stroke"`
+	Fill      *fillRecord  `json:"fill,omitempty"`
+	Editable  []string     `json:"editable,omitempty"`
+	// Children are the elements of a group, the position and dimension of a group are its frame
+	Children []elementRecord `json:"children,omitempty"`
+}
+
+type textRecord struct {
+	Content   string  `json:"content"`
+	Color     string  `json:"color"`
+	Size      float64 `json:"size,omitempty"`
+	Family    string  `json:"family,omitempty"`
+	Bold      bool    `json:"bold,omitempty"`
+	Italic    bool    `json:"italic,omitempty"`
+	Underline bool    `json:"underline,omitempty"`
+	Align     string  `json:"align,omitempty"`
+	VAlign    string  `json:"valign,omitempty"`
+}
+
+// align names used in the JSON document, center is left out
+var alignString = map[Align]string{
+	AlignLeft:  "left",
+	AlignRight: "right",
+}
+
+// vertical align names used in the JSON document, middle is left out
+var valignString = map[VAlign]string{
+	VAlignTop:    "top",
+	VAlignBottom: "bottom",
+}
+
+type strokeRecord struct {
+	Color     string    `json:"color"`
+	Thickness Thickness `json:"thickness"`
+}
+
+func newTextRecord(t Text) *textRecord {
+	return &textRecord{
+		Content:   t.Content,
+		Color:     t.Color,
+		Size:      t.Size,
+		Family:    t.Family,
+		Bold:      t.Bold,
+		Italic:    t.Italic,
+		Underline: t.Underline,
+		Align:     alignString[t.Align],
+		VAlign:    valignString[t.VAlign],
+	}
+}
+
+func (rec *textRecord) text() Text {
+	if rec == nil {
+		return Text{Color: DARKGREY}
+	}
+	t := Text{
+		Content:   rec.Content,
+		Color:     rec.Color,
+		Size:      rec.Size,
+		Family:    rec.Family,
+		Bold:      rec.Bold,
+		Italic:    rec.Italic,
+		Underline: rec.Underline,
+	}
+	for align, name := range alignString {
+		if rec.Align == name {
+			t.Align = align
+		}
+	}
+	for valign, name := range valignString {
+		if rec.VAlign == name {
+			t.VAlign = valign
+		}
+	}
+	return t
+}
+
+type fillRecord struct {
+	Color   string  `json:"color"`
+	Opacity float64 `json:"opacity"`
+}
+
+func newFillRecord(f Fill) *fillRecord {
+	return &fillRecord{
+		Color:   f.Color,
+		Opacity: f.Opacity,
+	}
+}
+
+// fill returns the fill of the record, documents without one keep the default of the element
+func (rec *fillRecord) fill(def Fill) Fill {
+	if rec == nil {
+		return def
+	}
+	return Fill{
+		Color:   rec.Color,
+		Opacity: rec.Opacity,
+	}
+}
+
+func newStrokeRecord(s Stroke) strokeRecord {
+	return strokeRecord{
+		Color:     s.Color,
+		Thickness: s.Thickness,
+	}
+}
+
+func (rec strokeRecord) stroke() Stroke {
+	return Stroke{
+		Color:     rec.Color,
+		Thickness: rec.Thickness,
+	}
+}
+
+// rename gives the record the id, the children of a group are numbered after it
+func (rec *elementRecord) rename(id string) {
+	rec.ID = id
+	for k := range rec.Children {
+		rec.Children[k].rename(id + "_" + strconv.Itoa(k))
+	}
+}
+
+// moveBy moves the record and its children
+func (rec *elementRecord) moveBy(dx, dy float64) {
+	rec.Position.X += dx
+	rec.Position.Y += dy
+	for k := range rec.Children {
+		rec.Children[k].moveBy(dx, dy)
+	}
+}
+
+// Marshal encodes the elements, in z-order, as a versioned mockup document.
+// Editing wrappers such as ScaleBox are saved as the element they wrap.
+func Marshal(elements []MockupElement) ([]byte, error) {
+	doc := documentRecord{
+		Version:  FormatVersion,
+		Elements: make([]elementRecord, 0, len(elements)),
+	}
+	for _, ele := range elements {
+		rec, err := newElementRecord(ele)
+		if err != nil {
+			return nil, err
+		}
+		doc.Elements = append(doc.Elements, rec)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// Render lays the elements out in an Svg whose view box fits them, with a margin around
+func Render(elements []MockupElement, margin float64) svg.Svg {
+	bounds := svg.BBox{}
+	content := make([]svg.SvgElement, 0, len(elements))
+	for k, ele := range elements {
+		se := ele.Svg()
+		// the bounding box of the svg covers rotated elements
+		if b := se.BBox(); k == 0 {
+			bounds = b
+		} else {
+			bounds = bounds.Union(b)
+		}
+		content = append(content, se)
+	}
+
+	vb := &svg.ViewBox{
+		X:      bounds.X - margin,
+		Y:      bounds.Y - margin,
+		Width:  bounds.Width + 2*margin,
+		Height: bounds.Height + 2*margin,
+	}
+	return svg.Svg{
+		Width:   vb.Width,
+		Height:  vb.Height,
+		ViewBox: vb,
+		Content: content,
+	}
+}
+
+// Unmarshal decodes a mockup document and rebuilds its elements in order
+func Unmarshal(data []byte) ([]MockupElement, error) {
+	doc := documentRecord{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Version < 1 || doc.Version > FormatVersion {
+		return nil, fmt.Errorf("mockup: unsupported format version %d", doc.Version)
+	}
+	elements := make([]MockupElement, 0, len(doc.Elements))
+	for _, rec := range doc.Elements {
+		ele, err := rec.element()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, ele)
+	}
+	return elements, nil
+}
+
+func unwrap(ele MockupElement) MockupElement {
+	switch e := ele.(type) {
+	case *ScaleBox:
+		return unwrap(e.MockupElement)
+	case *SelectBox:
+		return unwrap(e.MockupElement)
+	case *ScaleLine:
+		return e.line
+	case *CloneBox:
+		return unwrap(e.MockupElement)
+	}
+	return ele
+}
+
+func newElementRecord(ele MockupElement) (elementRecord, error) {
+	rec := elementRecord{}
+	ele = unwrap(ele)
+	switch e := ele.(type) {
+	case *textBox:
+		rec = elementRecord{Type: TextBoxType, Text: newTextRecord(e.Text), Stroke: newStrokeRecord(e.Stroke), Fill: newFillRecord(e.Fill)}
+		rec.Editable = e.editable.Editable.Classes()
+	case *button:
+		rec = elementRecord{Type: ButtonType, Text: newTextRecord(e.Text), Stroke: newStrokeRecord(e.Stroke), Fill: newFillRecord(e.Fill)}
+		rec.Editable = e.editable.Editable.Classes()
+	case *label:
+		rec = elementRecord{Type: LabelType, Text: newTextRecord(e.Text), Stroke: newStrokeRecord(e.Stroke), Fill: newFillRecord(e.Fill)}
+		rec.Editable = e.editable.Editable.Classes()
+	case *box:
+		rec = elementRecord{Type: BoxType, Stroke: newStrokeRecord(e.Stroke), Fill: newFillRecord(e.Fill)}
+		rec.Editable = e.editable.Editable.Classes()
+	case *line:
+		rec = elementRecord{Type: LineType, Stroke: newStrokeRecord(e.Stroke)}
+		rec.Editable = e.editable.Editable.Classes()
+	case *group:
+		rec = elementRecord{Type: GroupType}
+		for _, child := range e.children {
+			c, err := newElementRecord(child)
+			if err != nil {
+				return rec, err
+			}
+			c.moveBy(e.offset.X, e.offset.Y)
+			rec.Children = append(rec.Children, c)
+		}
+		rec.Editable = e.editable.Editable.Classes()
+	default:
+		return rec, fmt.Errorf("mockup: cannot encode element %q of type %T", ele.Id(), ele)
+	}
+	base := ele.GetBase()
+	rec.ID = ele.Id()
+	rec.Position = base.Position
+	rec.Dimension = base.Dimension
+	rec.Rotation = base.Rotation
+	return rec, nil
+}
+
+func (rec elementRecord) element() (MockupElement, error) {
+	ele, err := rec.newElement()
+	if err != nil {
+		return nil, err
+	}
+	if rec.Rotation != 0 {
+		ele.RotateTo(rec.Rotation)
+	}
+	return ele, nil
+}
+
+func (rec elementRecord) newElement() (MockupElement, error) {
+	w, h, x, y := rec.Dimension.Width, rec.Dimension.Height, rec.Position.X, rec.Position.Y
+	e := svg.ParseEditable(rec.Editable...)
+	text, stroke := rec.Text.text(), rec.Stroke.stroke()
+
+	switch rec.Type {
+	case TextBoxType:
+		ele := NewTextBox(w, h, x, y, text.Content, rec.ID, e)
+		ele.Text, ele.Stroke, ele.Fill = text, stroke, rec.Fill.fill(ele.Fill)
+		return ele, nil
+	case ButtonType:
+		ele := NewButton(w, h, x, y, text.Content, rec.ID, e)
+		ele.Text, ele.Stroke, ele.Fill = text, stroke, rec.Fill.fill(ele.Fill)
+		return ele, nil
+	case LabelType:
+		ele := NewLabel(w, h, x, y, text.Content, rec.ID, e)
+		ele.Text, ele.Stroke, ele.Fill = text, stroke, rec.Fill.fill(ele.Fill)
+		return ele, nil
+	case BoxType:
+		ele := NewBox(w, h, x, y, rec.ID, e)
+		ele.Stroke, ele.Fill = stroke, rec.Fill.fill(ele.Fill)
+		return ele, nil
+	case LineType:
+		ele := NewLine(w, h, x, y, rec.ID)
+		ele.Stroke = stroke
+		ele.SetEditable(e)
+		return ele, nil
+	case GroupType:
+		children := make([]MockupElement, 0, len(rec.Children))
+		for _, c := range rec.Children {
+			child, err := c.element()
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		ele := NewGroup(children, rec.ID, e)
+		// the frame of the record is the one of an edited group
+		if gw, gh, gx, gy := ele.GetWHXY(); gw != w || gh != h || gx != x || gy != y {
+			ele.ResizeTo(x, y, w, h)
+		}
+		return ele, nil
+	}
+	return nil, fmt.Errorf("mockup: unknown element type %q", rec.Type)
+}
@@ -0,0 +1,84 @@
+package mockup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	e := svg.DRAGGABLE | svg.EDITABLE
+	rotated := NewBox(40, 20, 10, 10, "rotated", e)
+	rotated.RotateTo(30)
+	styled := NewLabel(100, 40, 0, 50, "first\nsecond", "styled", e)
+	styled.Text.Size, styled.Text.Family, styled.Text.Bold, styled.Text.Align = 20, "serif", true, AlignRight
+	moved := NewGroup([]MockupElement{NewBox(10, 10, 0, 0, "m_0", e), NewLine(10, 10, 20, 0, "m_1")}, "moved", e)
+	moved.MoveTo(100, 100)
+	nested := NewGroup([]MockupElement{
+		NewButton(60, 20, 0, 0, "OK", "n_0", e),
+		NewGroup([]MockupElement{NewTextBox(60, 20, 0, 30, "name", "n_1_0", e), NewLabel(60, 20, 0, 60, "hint", "n_1_1", e)}, "n_1", e),
+	}, "nested", e)
+
+	cases := []struct {
+		name     string
+		elements []MockupElement
+	}{
+		{"empty", []MockupElement{}},
+		{"widgets", []MockupElement{NewTextBox(80, 20, 0, 0, "text", "t", e), NewButton(80, 20, 0, 30, "OK", "b", e), NewLine(50, 0, 0, 60, "l")}},
+		{"rotation", []MockupElement{rotated}},
+		{"text style", []MockupElement{styled}},
+		{"moved group", []MockupElement{moved}},
+		{"nested groups", []MockupElement{nested}},
+		{"selection wrapper", []MockupElement{NewScaleBox(NewBox(10, 10, 5, 5, "wrapped", e))}},
+	}
+	for _, c := range cases {
+		data, err := Marshal(c.elements)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		elements, err := Unmarshal(data)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if len(elements) != len(c.elements) {
+			t.Errorf("%s: %d elements, want %d", c.name, len(elements), len(c.elements))
+			continue
+		}
+		for k, ele := range elements {
+			if got, want := ele.GetBase(), unwrap(c.elements[k]).GetBase(); got != want {
+				t.Errorf("%s: %s at %v, want %v", c.name, ele.Id(), got, want)
+			}
+			// a moved group is loaded with its children where they are drawn
+			if got, want := ele.Svg().BBox(), unwrap(c.elements[k]).Svg().BBox(); got != want {
+				t.Errorf("%s: %s draws in %v, want %v", c.name, ele.Id(), got, want)
+			}
+		}
+		again, err := Marshal(elements)
+		if err != nil || string(again) != string(data) {
+			t.Errorf("%s: saved again as\n%s\nwant\n%s", c.name, again, data)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"not json", `<svg/>`, "invalid character"},
+		{"no version", `{"elements": []}`, "unsupported format version 0"},
+		{"newer version", `{"version": 2, "elements": []}`, "unsupported format version 2"},
+		{"unknown type", `{"version": 1, "elements": [{"type": "slider", "id": "s"}]}`, `unknown element type "slider"`},
+		{"unknown child type", `{"version": 1, "elements": [{"type": "group", "id": "g", "children": [{"type": "slider"}]}]}`, `unknown element type "slider"`},
+	}
+	for _, c := range cases {
+		_, err := Unmarshal([]byte(c.data))
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: error %v, want %q", c.name, err, c.err)
+		}
+	}
+}
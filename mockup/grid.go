@@ -0,0 +1,97 @@
+package mockup
+
+import (
+	"math"
+
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// Grid is drawn on the canvas. Elements moved, resized or dropped on the canvas snap to its lines.
+type Grid struct {
+	// Size is the distance between the main lines
+	Size float64
+	// Subdivisions is the number of cells between two main lines, elements snap to the lines dividing them
+	Subdivisions int
+	Color        string
+}
+
+func NewGrid(size float64, subdivisions int, color string) *Grid {
+	return &Grid{
+		Size:         size,
+		Subdivisions: subdivisions,
+		Color:        color,
+	}
+}
+
+// Step is the distance between the lines elements snap to
+func (g *Grid) Step() float64 {
+	if g.Subdivisions < 1 {
+		return g.Size
+	}
+	return g.Size / float64(g.Subdivisions)
+}
+
+// Snap returns the line nearest to v, lines are counted from origin
+func (g *Grid) Snap(v, origin float64) float64 {
+	s := g.Step()
+	if s <= 0 {
+		return v
+	}
+	return origin + math.Round((v-origin)/s)*s
+}
+
+// Svg draws the grid over the box, starting from its top left corner. The subdivision lines are thinner.
+// Like the canvas, the grid starts a rubber band selection.
+func (g *Grid) Svg(b svg.BBox, id string) svg.SvgElement {
+	content := []svg.SvgElement{}
+	if g.Step() > 0 {
+		// main lines over the subdivisions
+		content = append(g.lines(b, true), g.lines(b, false)...)
+	}
+	return &svg.Group{
+		Content:  content,
+		Editable: svg.SELECTION_AREA,
+		IDAble:   svg.IDAble{ID: id},
+	}
+}
+
+// lines are the subdivision lines of the grid, or its main lines
+func (g *Grid) lines(b svg.BBox, minor bool) []svg.SvgElement {
+	s := g.Step()
+	width := stroke_width / 2
+	if minor {
+		width = stroke_width / 4
+	}
+	lines := []svg.SvgElement{}
+	for k := 0; float64(k)*s <= b.Width; k++ {
+		if g.main(k) != minor {
+			x := b.X + float64(k)*s
+			lines = append(lines, g.line(x, b.Y, x, b.Bottom(), width))
+		}
+	}
+	for k := 0; float64(k)*s <= b.Height; k++ {
+		if g.main(k) != minor {
+			y := b.Y + float64(k)*s
+			lines = append(lines, g.line(b.X, y, b.Right(), y, width))
+		}
+	}
+	return lines
+}
+
+// main reports whether the k-th line is a main line
+func (g *Grid) main(k int) bool {
+	return g.Subdivisions < 2 || k%g.Subdivisions == 0
+}
+
+func (g *Grid) line(x1, y1, x2, y2, width float64) *svg.Line {
+	return &svg.Line{
+		X1: x1,
+		Y1: y1,
+		X2: x2,
+		Y2: y2,
+		Strokeable: svg.Strokeable{
+			Stroke:      g.Color,
+			StrokeWidth: width,
+		},
+	}
+}
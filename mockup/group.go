@@ -0,0 +1,148 @@
+package mockup
+
+import (
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// group is a compound element. Its frame is the union of its children's,
+// it moves and resizes them as one and can hold other groups.
+// The children are placed relative to the offset of the group, moving the group only changes its offset.
+type group struct {
+	idable
+	editable
+	children []MockupElement
+	offset   Position
+}
+
+func NewGroup(children []MockupElement, id string, e svg.Editable) *group {
+	return &group{
+		idable:   idable{id: id},
+		editable: editable{Editable: e},
+		children: append([]MockupElement{}, children...),
+	}
+}
+
+// Children returns the elements of the group in z-order, bottom first
+func (ele *group) Children() []MockupElement {
+	return append([]MockupElement{}, ele.children...)
+}
+
+func (ele *group) GetWHXY() (float64, float64, float64, float64) {
+	b := frameOf(ele.children)
+	return b.Width, b.Height, b.X + ele.offset.X, b.Y + ele.offset.Y
+}
+
+func (ele *group) GetBase() BaseElement {
+	return newBaseElement(ele.GetWHXY())
+}
+
+// MoveTo translates the group, its children stay in place within it
+func (ele *group) MoveTo(x, y float64) {
+	b := frameOf(ele.children)
+	ele.offset = Position{X: x - b.X, Y: y - b.Y}
+	ele.Svg().(*svg.Group).SetTransform(ele.transformable().Transform)
+}
+
+// ResizeTo scales the children, a group resized to its own size is only moved
+func (ele *group) ResizeTo(x, y, w, h float64) {
+	if b := frameOf(ele.children); w == b.Width && h == b.Height {
+		ele.MoveTo(x, y)
+		return
+	}
+	scaleAll(ele.children, x-ele.offset.X, y-ele.offset.Y, w, h)
+}
+
+// RotateTo does nothing, the children of a group are turned one by one
+func (ele *group) RotateTo(angle float64) {
+}
+
+// flatten moves the offset of the group into its children, they are then placed where they are drawn.
+// Nothing moves on the page.
+func (ele *group) flatten() {
+	if ele.offset == (Position{}) {
+		return
+	}
+	offset := ele.offset
+	ele.offset = Position{}
+	for _, child := range ele.children {
+		shiftBy(child, offset.X, offset.Y)
+	}
+	ele.Svg().(*svg.Group).SetTransform(nil)
+}
+
+// shiftBy moves the element by dx, dy
+func shiftBy(ele MockupElement, dx, dy float64) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+	_, _, x, y := ele.GetWHXY()
+	ele.MoveTo(x+dx, y+dy)
+}
+
+// transformable translates the children by the offset
+func (ele *group) transformable() svg.Transformable {
+	if ele.offset == (Position{}) {
+		return svg.Transformable{}
+	}
+	return svg.Transformable{Transform: svg.Transform{svg.Translate(ele.offset.X, ele.offset.Y)}}
+}
+
+// Svg draws the children in a group translated by the offset, they are placed by their own transforms
+func (ele *group) Svg() svg.SvgElement {
+	content := make([]svg.SvgElement, len(ele.children))
+	for k, child := range ele.children {
+		content[k] = child.Svg()
+	}
+	return &svg.Group{
+		Content:       content,
+		Editable:      ele.editable.Editable,
+		Transformable: ele.transformable(),
+		IDAble: svg.IDAble{
+			ID: ele.id,
+		},
+	}
+}
+
+// frameOf is the union of the boxes of the elements, selection wrappers are framed as the element they wrap
+func frameOf(elements []MockupElement) svg.BBox {
+	if len(elements) == 0 {
+		return svg.BBox{}
+	}
+	b := unwrap(elements[0]).Svg().BBox()
+	for _, e := range elements[1:] {
+		b = b.Union(unwrap(e).Svg().BBox())
+	}
+	return b
+}
+
+// moveAll moves the elements together so that their frame starts at x, y
+func moveAll(elements []MockupElement, x, y float64) {
+	b := frameOf(elements)
+	dx, dy := x-b.X, y-b.Y
+	for _, e := range elements {
+		_, _, ex, ey := e.GetWHXY()
+		e.MoveTo(ex+dx, ey+dy)
+	}
+}
+
+// scaleAll maps the frame of the elements to x, y, w, h. The centers of the elements follow the frame
+// and their sizes are scaled by as much as the frame.
+func scaleAll(elements []MockupElement, x, y, w, h float64) {
+	b := frameOf(elements)
+	sx, sy := scaleOf(w, b.Width), scaleOf(h, b.Height)
+	for _, e := range elements {
+		ew, eh, ex, ey := e.GetWHXY()
+		cx := x + (ex+ew/2-b.X)*sx
+		cy := y + (ey+eh/2-b.Y)*sy
+		ew, eh = ew*sx, eh*sy
+		e.ResizeTo(cx-ew/2, cy-eh/2, ew, eh)
+	}
+}
+
+// scaleOf is the scale from d to n, 1 when d is empty
+func scaleOf(n, d float64) float64 {
+	if d == 0 {
+		return 1
+	}
+	return n / d
+}
@@ -0,0 +1,66 @@
+package mockup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+func TestGroupHasNoRotationHandle(t *testing.T) {
+	cases := []struct {
+		name   string
+		ele    MockupElement
+		handle bool
+	}{
+		{"box", NewBox(10, 10, 0, 0, "a", 0), true},
+		{"group", NewGroup([]MockupElement{NewBox(10, 10, 0, 0, "a", 0), NewBox(10, 10, 20, 0, "b", 0)}, "g", 0), false},
+	}
+	for _, c := range cases {
+		sb := NewScaleBox(c.ele)
+		if got := strings.Contains(sb.Svg().String(), `id="rot_`); got != c.handle {
+			t.Errorf("%s: rotation handle %v, want %v", c.name, got, c.handle)
+		}
+		// the controls are laid out without the handle
+		sb.MoveTo(5, 5)
+	}
+}
+
+func TestGroupMoveTranslates(t *testing.T) {
+	newGroup := func() *group {
+		return NewGroup([]MockupElement{NewBox(10, 10, 0, 0, "a", 0), NewBox(10, 10, 20, 0, "b", 0)}, "g", 0)
+	}
+	cases := []struct {
+		name string
+		edit func(g *group)
+		// child is the position of the first child within the group
+		child     Position
+		transform string
+	}{
+		{"move", func(g *group) { g.MoveTo(5, 7) }, Position{0, 0}, "translate(5 7)"},
+		{"resize to its size", func(g *group) { g.ResizeTo(5, 7, 30, 10) }, Position{0, 0}, "translate(5 7)"},
+		{"flatten", func(g *group) { g.MoveTo(5, 7); g.flatten() }, Position{5, 7}, ""},
+		{"resize", func(g *group) { g.MoveTo(5, 7); g.ResizeTo(5, 7, 60, 20) }, Position{0, 0}, "translate(5 7)"},
+	}
+	for _, c := range cases {
+		g := newGroup()
+		c.edit(g)
+		if _, _, x, y := g.GetWHXY(); x != 5 || y != 7 {
+			t.Errorf("%s: group at %v, %v, want 5, 7", c.name, x, y)
+		}
+		if _, _, x, y := g.children[0].GetWHXY(); x != c.child.X || y != c.child.Y {
+			t.Errorf("%s: child at %v, %v, want %v", c.name, x, y, c.child)
+		}
+		if got := g.Svg().(*svg.Group).Transform.String(); got != c.transform {
+			t.Errorf("%s: transform %q, want %q", c.name, got, c.transform)
+		}
+		// the saved children are where they are drawn
+		rec, err := newElementRecord(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p := rec.Children[0].Position; p.X != 5 || p.Y != 7 {
+			t.Errorf("%s: saved child at %v, want 5, 7", c.name, p)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+//go:build js
+
+package mockup
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gopherjs/jquery"
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// guideThreshold is how close an edge or a center gets to a guide before it snaps to it
+const guideThreshold = 5
+
+// guideEpsilon is how close two positions are to be shown as aligned
+const guideEpsilon = 0.5
+
+// guideLayerId is the id of the overlay drawing the guides over the content
+const guideLayerId = "guides"
+
+var GUIDE = "#E91E63"
+
+// guideIndex holds the boxes of the elements a gesture aligns to, with their edges and centers sorted by axis.
+// The vertical guides of the flipped boxes are the horizontal guides of the boxes.
+type guideIndex struct {
+	boxes   []svg.BBox
+	flipped []svg.BBox
+	xs      []float64
+	ys      []float64
+}
+
+func newGuideIndex(boxes []svg.BBox) *guideIndex {
+	idx := &guideIndex{boxes: boxes}
+	for _, b := range boxes {
+		idx.flipped = append(idx.flipped, flip(b))
+		idx.xs = append(idx.xs, stops(b)...)
+		idx.ys = append(idx.ys, stops(flip(b))...)
+	}
+	sort.Float64s(idx.xs)
+	sort.Float64s(idx.ys)
+	return idx
+}
+
+// align returns the shift of b putting one of its edges or its center on a guide, axis by axis.
+// With spacing, b can also snap to repeat the gap between the boxes level with it, or to center between its neighbors.
+func (idx *guideIndex) align(b svg.BBox, spacing bool) (dx, dy float64, okx, oky bool) {
+	dx, okx = shift(idx.xs, idx.boxes, b, spacing)
+	dy, oky = shift(idx.ys, idx.flipped, flip(b), spacing)
+	return
+}
+
+// guides are the lines showing where b is aligned on other boxes, and the gaps around b equal to others
+func (idx *guideIndex) guides(b svg.BBox) []svg.SvgElement {
+	lines := []svg.SvgElement{}
+	for _, s := range alignments(idx.boxes, b) {
+		lines = append(lines, s.line(false))
+	}
+	for _, s := range alignments(idx.flipped, flip(b)) {
+		lines = append(lines, s.flip().line(false))
+	}
+	for _, s := range gaps(idx.boxes, b) {
+		lines = append(lines, s.line(true))
+	}
+	for _, s := range gaps(idx.flipped, flip(b)) {
+		lines = append(lines, s.flip().line(true))
+	}
+	return lines
+}
+
+// stops are the left edge, the center and the right edge of the box
+func stops(b svg.BBox) []float64 {
+	return []float64{b.X, b.X + b.Width/2, b.Right()}
+}
+
+func flip(b svg.BBox) svg.BBox {
+	return svg.BBox{X: b.Y, Y: b.X, Width: b.Height, Height: b.Width}
+}
+
+// shift is the nearest horizontal move of b within the threshold putting it on a guide
+func shift(guides []float64, boxes []svg.BBox, b svg.BBox, spacing bool) (float64, bool) {
+	best, d, found := float64(guideThreshold), 0.0, false
+	try := func(delta float64) {
+		if math.Abs(delta) <= best {
+			best, d, found = math.Abs(delta), delta, true
+		}
+	}
+	for _, s := range stops(b) {
+		if v, ok := nearest(guides, s); ok {
+			try(v - s)
+		}
+	}
+	if spacing {
+		for _, x := range spacings(boxes, b) {
+			try(x - b.X)
+		}
+	}
+	return d, found
+}
+
+// nearest returns the value of the sorted values nearest to v
+func nearest(values []float64, v float64) (float64, bool) {
+	i := sort.SearchFloat64s(values, v)
+	switch {
+	case len(values) == 0:
+		return 0, false
+	case i == 0:
+		return values[0], true
+	case i == len(values):
+		return values[i-1], true
+	case values[i]-v < v-values[i-1]:
+		return values[i], true
+	}
+	return values[i-1], true
+}
+
+// spacings are the left edges putting b after or before two boxes level with it, as far from them as they are apart,
+// and the left edge centering b between its neighbors
+func spacings(boxes []svg.BBox, b svg.BBox) []float64 {
+	row := level(boxes, b)
+	xs := []float64{}
+	for _, l := range row {
+		for _, r := range row {
+			if gap := r.X - l.Right(); gap > 0 {
+				xs = append(xs, r.Right()+gap, l.X-gap-b.Width)
+			}
+		}
+	}
+	l, okl := neighbor(row, b, false)
+	r, okr := neighbor(row, b, true)
+	if okl && okr {
+		xs = append(xs, (l.Right()+r.X-b.Width)/2)
+	}
+	return xs
+}
+
+// level returns the boxes overlapping b vertically
+func level(boxes []svg.BBox, b svg.BBox) []svg.BBox {
+	row := []svg.BBox{}
+	for _, o := range boxes {
+		if o.Y <= b.Bottom() && b.Y <= o.Bottom() {
+			row = append(row, o)
+		}
+	}
+	return row
+}
+
+// neighbor returns the nearest box level with b on its right, or on its left
+func neighbor(boxes []svg.BBox, b svg.BBox, right bool) (svg.BBox, bool) {
+	n, found := svg.BBox{}, false
+	for _, o := range level(boxes, b) {
+		switch {
+		case right && o.X >= b.Right()-guideEpsilon && (!found || o.X < n.X):
+			n, found = o, true
+		case !right && o.Right() <= b.X+guideEpsilon && (!found || o.Right() > n.Right()):
+			n, found = o, true
+		}
+	}
+	return n, found
+}
+
+// segment is a guide line drawn from x1, y1 to x2, y2
+type segment struct {
+	x1, y1, x2, y2 float64
+}
+
+func (s segment) flip() segment {
+	return segment{x1: s.y1, y1: s.x1, x2: s.y2, y2: s.x2}
+}
+
+// line draws the segment, dashed for a gap
+func (s segment) line(dashed bool) *svg.Line {
+	l := &svg.Line{
+		X1: s.x1,
+		Y1: s.y1,
+		X2: s.x2,
+		Y2: s.y2,
+		Strokeable: svg.Strokeable{
+			Stroke:      GUIDE,
+			StrokeWidth: stroke_width / 2,
+		},
+	}
+	if dashed {
+		l.StrokeDashArray = []float64{4, 2}
+	}
+	return l
+}
+
+// alignments are the vertical lines through the edges and the center of b shared with other boxes,
+// spanning all of them
+func alignments(boxes []svg.BBox, b svg.BBox) []segment {
+	segments := []segment{}
+	done := map[float64]bool{}
+	for _, s := range stops(b) {
+		if done[s] {
+			continue
+		}
+		done[s] = true
+		y1, y2, found := b.Y, b.Bottom(), false
+		for _, o := range boxes {
+			for _, os := range stops(o) {
+				if math.Abs(os-s) < guideEpsilon {
+					y1, y2, found = math.Min(y1, o.Y), math.Max(y2, o.Bottom()), true
+				}
+			}
+		}
+		if found {
+			segments = append(segments, segment{x1: s, y1: y1, x2: s, y2: y2})
+		}
+	}
+	return segments
+}
+
+// gaps are the horizontal gaps between b and its neighbors equal to a gap between two other boxes level with b,
+// with those gaps
+func gaps(boxes []svg.BBox, b svg.BBox) []segment {
+	type pair struct {
+		l, r svg.BBox
+		gap  float64
+		ofB  bool
+	}
+	row := append(level(boxes, b), b)
+	pairs := []pair{}
+	for k, l := range row {
+		r, ok := neighbor(append(append([]svg.BBox{}, row[:k]...), row[k+1:]...), l, true)
+		if !ok || r.X-l.Right() <= 0 {
+			continue
+		}
+		pairs = append(pairs, pair{l: l, r: r, gap: r.X - l.Right(), ofB: l == b || r == b})
+	}
+	shown := map[int]bool{}
+	for i, p := range pairs {
+		if !p.ofB {
+			continue
+		}
+		for j, q := range pairs {
+			if i != j && math.Abs(p.gap-q.gap) < guideEpsilon {
+				shown[i], shown[j] = true, true
+			}
+		}
+	}
+	segments := []segment{}
+	for i, p := range pairs {
+		if shown[i] {
+			y := (math.Max(p.l.Y, p.r.Y) + math.Min(p.l.Bottom(), p.r.Bottom())) / 2
+			segments = append(segments, segment{x1: p.l.Right(), y1: y, x2: p.r.X, y2: y})
+		}
+	}
+	return segments
+}
+
+// guidesOf indexes the elements of the scope not edited by the gesture
+func (ed *ControlEditable) guidesOf(moving map[string]BaseElement) *guideIndex {
+	boxes := []svg.BBox{}
+	for _, ele := range ed.doc.Scope() {
+		ele = unwrap(ele)
+		if _, ok := moving[ele.Id()]; !ok {
+			boxes = append(boxes, ele.Svg().BBox())
+		}
+	}
+	return newGuideIndex(boxes)
+}
+
+// align places the element moved to x, y on the guides of the gesture, or else on the grid, axis by axis.
+// Holding Alt places it where it is dropped.
+func (ed *ControlEditable) align(e jquery.Event, ele MockupElement, x, y float64) (float64, float64) {
+	_, _, ex, ey := ele.GetWHXY()
+	b := ele.Svg().BBox()
+	b.X, b.Y = b.X+x-ex, b.Y+y-ey
+	dx, dy := ed.alignBox(e, b, true)
+	return x + dx, y + dy
+}
+
+// alignPoint places a point dragged by a resize square or a line vertex on the guides, or else on the grid
+func (ed *ControlEditable) alignPoint(e jquery.Event, x, y float64) (float64, float64) {
+	dx, dy := ed.alignBox(e, svg.BBox{X: x, Y: y}, false)
+	return x + dx, y + dy
+}
+
+// alignBox returns the shift of b onto the guides or the grid, and shows the guides it is aligned on
+func (ed *ControlEditable) alignBox(e jquery.Event, b svg.BBox, spacing bool) (float64, float64) {
+	g := ed.gesture
+	if e.Get("altKey").Bool() || g == nil || g.guides == nil {
+		ed.showGuides(nil)
+		x, y := ed.snap(e, b.X, b.Y)
+		return x - b.X, y - b.Y
+	}
+	dx, dy, okx, oky := g.guides.align(b, spacing)
+	x, y := ed.snap(e, b.X, b.Y)
+	if !okx {
+		dx = x - b.X
+	}
+	if !oky {
+		dy = y - b.Y
+	}
+	b.X, b.Y = b.X+dx, b.Y+dy
+	ed.showGuides(g.guides.guides(b))
+	return dx, dy
+}
+
+// showGuides draws the lines in the overlay, over the content of the page
+func (ed *ControlEditable) showGuides(lines []svg.SvgElement) {
+	layer := jQuery("#" + guideLayerId)
+	if len(lines) == 0 {
+		layer.Remove()
+		return
+	}
+	if layer.Length == 0 {
+		layer = (&svg.Group{IDAble: svg.IDAble{ID: guideLayerId}}).JQ()
+		jQuery("svg").Append(layer)
+	}
+	layer.Empty()
+	for _, l := range lines {
+		layer.Append(svg.JQ(l))
+	}
+}
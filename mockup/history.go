@@ -0,0 +1,346 @@
+package mockup
+
+import (
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// Command is a reversible edit of a Document
+type Command interface {
+	Do()
+	Undo()
+}
+
+var historyLimit = 200
+
+// History is an undo/redo stack of commands
+type History struct {
+	undo []Command
+	redo []Command
+}
+
+func NewHistory() *History {
+	return &History{}
+}
+
+// Do applies the command and records it
+func (h *History) Do(c Command) {
+	c.Do()
+	h.Push(c)
+}
+
+// Push records a command that has already been applied
+func (h *History) Push(c Command) {
+	h.undo = append(h.undo, c)
+	if len(h.undo) > historyLimit {
+		h.undo = h.undo[len(h.undo)-historyLimit:]
+	}
+	h.redo = nil
+}
+
+func (h *History) CanUndo() bool {
+	return len(h.undo) > 0
+}
+
+func (h *History) CanRedo() bool {
+	return len(h.redo) > 0
+}
+
+func (h *History) Undo() bool {
+	if !h.CanUndo() {
+		return false
+	}
+	c := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	c.Undo()
+	h.redo = append(h.redo, c)
+	return true
+}
+
+func (h *History) Redo() bool {
+	if !h.CanRedo() {
+		return false
+	}
+	c := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	c.Do()
+	h.undo = append(h.undo, c)
+	return true
+}
+
+// batchCommand applies several commands as one history entry, undoing them in reverse order
+type batchCommand []Command
+
+func newBatchCommand(commands ...Command) batchCommand {
+	return batchCommand(commands)
+}
+
+func (c batchCommand) Do() {
+	for _, cmd := range c {
+		cmd.Do()
+	}
+}
+
+func (c batchCommand) Undo() {
+	for k := len(c) - 1; k >= 0; k-- {
+		c[k].Undo()
+	}
+}
+
+// geometryCommand moves, resizes and turns an element
+type geometryCommand struct {
+	doc    *Document
+	id     string
+	before BaseElement
+	after  BaseElement
+}
+
+func newGeometryCommand(doc *Document, id string, before, after BaseElement) *geometryCommand {
+	return &geometryCommand{
+		doc:    doc,
+		id:     id,
+		before: before,
+		after:  after,
+	}
+}
+
+func (c *geometryCommand) Do() {
+	c.apply(c.after)
+}
+
+func (c *geometryCommand) Undo() {
+	c.apply(c.before)
+}
+
+func (c *geometryCommand) apply(be BaseElement) {
+	ele, ok := c.doc.Get(c.id)
+	if !ok {
+		return
+	}
+	selected := c.doc.IsSelected(c.id)
+	c.doc.Deselect(c.id)
+	w, h, x, y := be.GetWHXY()
+	ele.ResizeTo(x, y, w, h)
+	ele.RotateTo(be.Rotation)
+	if selected {
+		c.doc.Select(c.id)
+	}
+}
+
+// addCommand puts an element into the group with the parent id, or the content, at a z-order index.
+// The element goes back there whatever group is entered when the command is done again.
+type addCommand struct {
+	doc    *Document
+	parent string
+	index  int
+	ele    MockupElement
+}
+
+func newAddCommand(doc *Document, parent string, index int, ele MockupElement) *addCommand {
+	return &addCommand{
+		doc:    doc,
+		parent: parent,
+		index:  index,
+		ele:    ele,
+	}
+}
+
+func (c *addCommand) Do() {
+	c.doc.insertInto(c.parent, c.index, c.ele)
+}
+
+func (c *addCommand) Undo() {
+	c.doc.Remove(c.ele.Id())
+}
+
+// textCommand changes the text of a widget
+type textCommand struct {
+	doc    *Document
+	id     string
+	before Text
+	after  Text
+}
+
+func newTextCommand(doc *Document, id string, before, after Text) *textCommand {
+	return &textCommand{
+		doc:    doc,
+		id:     id,
+		before: before,
+		after:  after,
+	}
+}
+
+func (c *textCommand) Do() {
+	c.apply(c.after)
+}
+
+func (c *textCommand) Undo() {
+	c.apply(c.before)
+}
+
+func (c *textCommand) apply(t Text) {
+	ele, ok := c.doc.Get(c.id)
+	if !ok {
+		return
+	}
+	if te, ok := ele.(TextElement); ok {
+		te.SetText(t)
+	}
+}
+
+// recordCommand rebuilds an element from its record, changing any of its attributes
+type recordCommand struct {
+	doc    *Document
+	before elementRecord
+	after  elementRecord
+}
+
+func newRecordCommand(doc *Document, before, after elementRecord) *recordCommand {
+	return &recordCommand{
+		doc:    doc,
+		before: before,
+		after:  after,
+	}
+}
+
+func (c *recordCommand) Do() {
+	applyRecord(c.doc, c.after)
+}
+
+func (c *recordCommand) Undo() {
+	applyRecord(c.doc, c.before)
+}
+
+// applyRecord replaces the element with the id of the record by the one it describes, keeping it selected
+func applyRecord(doc *Document, rec elementRecord) {
+	ele, err := rec.element()
+	if err != nil {
+		return
+	}
+	selected := doc.IsSelected(rec.ID)
+	if !doc.Replace(rec.ID, ele) {
+		return
+	}
+	if selected {
+		doc.Select(rec.ID)
+	}
+}
+
+// reverseCommand undoes a command when done
+type reverseCommand struct {
+	Command
+}
+
+func (c reverseCommand) Do() {
+	c.Command.Undo()
+}
+
+func (c reverseCommand) Undo() {
+	c.Command.Do()
+}
+
+// groupCommand gathers elements of the same parent into a group, in their z-order.
+// The group takes the place of the topmost element.
+type groupCommand struct {
+	doc      *Document
+	id       string
+	editable svg.Editable
+	parent   string
+	ids      []string
+	indices  []int
+}
+
+// newGroupCommand groups the elements with the ids, it reports false when they are not in the same parent
+func newGroupCommand(doc *Document, id string, e svg.Editable, ids []string) (*groupCommand, bool) {
+	c := &groupCommand{doc: doc, id: id, editable: e}
+	indices := map[string]int{}
+	for k, v := range ids {
+		p, i := doc.find(v)
+		if i < 0 || (k > 0 && parentId(p) != c.parent) {
+			return nil, false
+		}
+		c.parent = parentId(p)
+		indices[v] = i
+	}
+	for i, ele := range doc.children(c.parent) {
+		if _, ok := indices[ele.Id()]; ok {
+			c.ids = append(c.ids, ele.Id())
+			c.indices = append(c.indices, i)
+		}
+	}
+	return c, len(c.ids) > 0
+}
+
+// newUngroupCommand puts the children of the group with the id in its place
+func newUngroupCommand(doc *Document, id string) (Command, bool) {
+	p, i := doc.find(id)
+	if i < 0 {
+		return nil, false
+	}
+	g, ok := doc.children(parentId(p))[i].(*group)
+	if !ok {
+		return nil, false
+	}
+	c := &groupCommand{doc: doc, id: id, editable: g.editable.Editable, parent: parentId(p)}
+	for k, child := range g.children {
+		c.ids = append(c.ids, child.Id())
+		c.indices = append(c.indices, i+k)
+	}
+	return reverseCommand{c}, true
+}
+
+func (c *groupCommand) Do() {
+	children := []MockupElement{}
+	for _, id := range c.ids {
+		if ele, ok := c.doc.Get(id); ok {
+			children = append(children, ele)
+		}
+	}
+	for k := len(c.ids) - 1; k >= 0; k-- {
+		c.doc.Remove(c.ids[k])
+	}
+	c.doc.insertInto(c.parent, c.indices[len(c.indices)-1]-len(c.indices)+1, NewGroup(children, c.id, c.editable))
+}
+
+func (c *groupCommand) Undo() {
+	ele, ok := c.doc.Remove(c.id)
+	if !ok {
+		return
+	}
+	g := ele.(*group)
+	g.flatten()
+	for k, child := range g.children {
+		if k < len(c.indices) {
+			c.doc.insertInto(c.parent, c.indices[k], child)
+		}
+	}
+}
+
+// orderCommand changes the z-order of the children of a group, or of the content
+type orderCommand struct {
+	doc    *Document
+	before []string
+	after  []string
+}
+
+func newOrderCommand(doc *Document, before, after []string) *orderCommand {
+	return &orderCommand{
+		doc:    doc,
+		before: before,
+		after:  after,
+	}
+}
+
+func (c *orderCommand) Do() {
+	c.apply(c.after)
+}
+
+func (c *orderCommand) Undo() {
+	c.apply(c.before)
+}
+
+// apply puts the elements with the ids in order from the bottom
+func (c *orderCommand) apply(ids []string) {
+	for k, id := range ids {
+		c.doc.Reorder(id, k)
+	}
+}
@@ -0,0 +1,76 @@
+package mockup
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+func ids(elements []MockupElement) []string {
+	s := []string{}
+	for _, e := range elements {
+		s = append(s, e.Id())
+	}
+	return s
+}
+
+func TestUndoInsertsIntoParent(t *testing.T) {
+	// the edits are made in the group G of A and B, then the group is left before undo and redo
+	cases := []struct {
+		name     string
+		edit     func(doc *Document) Command
+		scope    []string
+		children []string
+	}{
+		{
+			name: "undo delete",
+			edit: func(doc *Document) Command {
+				a, _ := doc.Get("A")
+				return reverseCommand{newAddCommand(doc, doc.scopeId(), doc.IndexOf("A"), a)}
+			},
+			scope:    []string{"G", "C"},
+			children: []string{"B"},
+		},
+		{
+			name: "redo duplicate",
+			edit: func(doc *Document) Command {
+				return newAddCommand(doc, doc.scopeId(), len(doc.Scope()), NewBox(10, 10, 0, 20, "M", 0))
+			},
+			scope:    []string{"G", "C"},
+			children: []string{"A", "B", "M"},
+		},
+	}
+	for _, c := range cases {
+		doc := NewDocument()
+		for k, id := range []string{"A", "B", "C"} {
+			doc.Add(NewBox(10, 10, float64(k)*50, 0, id, 0))
+		}
+		gc, ok := newGroupCommand(doc, "G", svg.EDITABLE|svg.DRAGGABLE, []string{"A", "B"})
+		if !ok {
+			t.Fatal("not grouped")
+		}
+		gc.Do()
+		ele, _ := doc.Get("G")
+		g := unwrap(ele).(*group)
+		doc.Enter("G")
+
+		h := NewHistory()
+		h.Do(c.edit(doc))
+		doc.ExitAll()
+		h.Undo()
+		h.Redo()
+		if got := ids(doc.Scope()); !reflect.DeepEqual(got, c.scope) {
+			t.Errorf("%s: content %v, want %v", c.name, got, c.scope)
+		}
+		if got := ids(g.children); !reflect.DeepEqual(got, c.children) {
+			t.Errorf("%s: group %v, want %v", c.name, got, c.children)
+		}
+
+		// undone again, the group is as it was before the edit
+		h.Undo()
+		if got := ids(g.children); !reflect.DeepEqual(got, []string{"A", "B"}) {
+			t.Errorf("%s: undone to %v, want [A B]", c.name, got)
+		}
+	}
+}
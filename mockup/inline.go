@@ -0,0 +1,155 @@
+//go:build js
+
+package mockup
+
+import (
+	"github.com/gopherjs/jquery"
+)
+
+const (
+	keyEnter  = 13
+	keyEscape = 27
+)
+
+// inlineEditor is the html textarea laid over a widget while its text is edited
+type inlineEditor struct {
+	id     string
+	before Text
+	input  jquery.JQuery
+}
+
+var inlineAlign = map[Align]string{
+	AlignCenter: "center",
+	AlignLeft:   "left",
+	AlignRight:  "right",
+}
+
+// Editing reports whether the text of a widget is being edited
+func (ed *ControlEditable) Editing() bool {
+	return ed.inline != nil
+}
+
+// startTextEditing opens the inline editor on the double-clicked widget,
+// a double-clicked group is entered to select the child under the mouse
+func (ed *ControlEditable) startTextEditing(e jquery.Event) {
+	id := jQuery(e.CurrentTarget).Attr("id")
+	if !ed.doc.InScope(id) {
+		return
+	}
+	ele, _ := ed.doc.Get(id)
+	if g, ok := ele.(*group); ok {
+		e.StopPropagation()
+		ed.enterGroup(g, jQuery(e.Target))
+		return
+	}
+	te, ok := ele.(TextElement)
+	if !ok {
+		return
+	}
+	e.StopPropagation()
+	ed.EditText(te, jQuery(e.CurrentTarget).Closest("svg"))
+}
+
+// enterGroup enters the group and selects its child holding the target
+func (ed *ControlEditable) enterGroup(g *group, target jquery.JQuery) {
+	if !ed.doc.Enter(g.Id()) {
+		return
+	}
+	for t := target; t.Length > 0; t = t.Parent() {
+		if id := t.Attr("id"); ed.doc.InScope(id) {
+			ed.doc.Select(id)
+			return
+		}
+		if t.Attr("id") == g.Id() {
+			return
+		}
+	}
+}
+
+// EditText lays a textarea over the widget, in the page svg, to edit its text.
+// Enter or leaving the textarea commits the text, Shift+Enter starts a new line and Escape cancels.
+func (ed *ControlEditable) EditText(te TextElement, page jquery.JQuery) {
+	ed.commitText()
+	t := te.GetText()
+	b := te.Svg().BBox()
+	origin := page.Offset()
+	f := t.fontable().Font()
+
+	input := jQuery("<textarea>").SetVal(t.Content).SetCss(map[string]interface{}{
+		"position":    "absolute",
+		"left":        px(float64(origin.Left) + b.X),
+		"top":         px(float64(origin.Top) + b.Y),
+		"width":       px(b.Width),
+		"height":      px(b.Height),
+		"box-sizing":  "border-box",
+		"padding":     px(textPadding),
+		"margin":      "0",
+		"border":      "1px solid " + DARKGREY,
+		"resize":      "none",
+		"overflow":    "hidden",
+		"font":        f.CSS(),
+		"line-height": formatFloat(lineSpacing),
+		"text-align":  inlineAlign[t.Align],
+		"color":       t.Color,
+		"background":  WHITE,
+	})
+	ed.inline = &inlineEditor{
+		id:     te.Id(),
+		before: t,
+		input:  input,
+	}
+	input.On(jquery.KEYDOWN, ed.inlineKeyDown)
+	input.On(jquery.BLUR, func(e jquery.Event) {
+		ed.commitText()
+	})
+	input.AppendTo("body")
+	input.Focus()
+	input.Select()
+}
+
+func (ed *ControlEditable) inlineKeyDown(e jquery.Event) {
+	// keep editing keys from the shortcuts of the page
+	e.StopPropagation()
+	switch {
+	case e.KeyCode == keyEnter && !e.ShiftKey:
+		e.PreventDefault()
+		ed.commitText()
+	case e.KeyCode == keyEscape:
+		e.PreventDefault()
+		ed.cancelText()
+	}
+}
+
+// commitText closes the inline editor, recording the new text in the history
+func (ed *ControlEditable) commitText() {
+	in := ed.closeText()
+	if in == nil {
+		return
+	}
+	after := in.before
+	after.Content = in.input.Val()
+	if after == in.before {
+		return
+	}
+	ed.History.Do(newTextCommand(ed.doc, in.id, in.before, after))
+}
+
+// cancelText closes the inline editor, leaving the text unchanged
+func (ed *ControlEditable) cancelText() {
+	ed.closeText()
+}
+
+func (ed *ControlEditable) closeText() *inlineEditor {
+	in := ed.inline
+	if in == nil {
+		return nil
+	}
+	// cleared first, removing the textarea blurs it
+	ed.inline = nil
+	in.input.Remove()
+	return in
+}
+
+func px(v float64) string {
+	return formatFloat(v) + "px"
+}
@@ -0,0 +1,201 @@
+//go:build js
+
+package mockup
+
+import (
+	"strings"
+
+	"github.com/gopherjs/jquery"
+)
+
+// key codes of the default shortcuts, letters and digits are their upper case character
+const (
+	keyBackspace    = 8
+	keyArrowLeft    = 37
+	keyArrowUp      = 38
+	keyArrowRight   = 39
+	keyArrowDown    = 40
+	keyDelete       = 46
+	keyBracketLeft  = 219
+	keyBracketRight = 221
+)
+
+var keyNames = map[int]string{
+	keyEnter:        "Enter",
+	keyEscape:       "Escape",
+	keyBackspace:    "Backspace",
+	keyArrowLeft:    "Left",
+	keyArrowUp:      "Up",
+	keyArrowRight:   "Right",
+	keyArrowDown:    "Down",
+	keyDelete:       "Delete",
+	keyBracketLeft:  "[",
+	keyBracketRight: "]",
+}
+
+// nudge distances, Shift nudges further
+const (
+	nudgeStep      = 1
+	nudgeShiftStep = 10
+)
+
+// copyOffset is how far a duplicate or a pasted copy is placed from its original
+const copyOffset = 10
+
+// Shortcut is a key code with the modifiers held. Ctrl stands for Cmd as well.
+type Shortcut struct {
+	Key   int
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+}
+
+func shortcutOf(e jquery.Event) Shortcut {
+	return Shortcut{
+		Key:   e.KeyCode,
+		Ctrl:  e.CtrlKey || e.MetaKey,
+		Shift: e.ShiftKey,
+		Alt:   e.Get("altKey").Bool(),
+	}
+}
+
+// String is the shortcut as shown to the user, e.g. Ctrl+Shift+Z
+func (s Shortcut) String() string {
+	keys := []string{}
+	if s.Ctrl {
+		keys = append(keys, "Ctrl")
+	}
+	if s.Alt {
+		keys = append(keys, "Alt")
+	}
+	if s.Shift {
+		keys = append(keys, "Shift")
+	}
+	name, ok := keyNames[s.Key]
+	if !ok {
+		name = string(rune(s.Key))
+	}
+	return strings.Join(append(keys, name), "+")
+}
+
+// Action is run by a shortcut on the editor, methods such as (*ControlEditable).Delete are actions
+type Action func(ed *ControlEditable)
+
+// Keymap binds shortcuts to actions
+type Keymap map[Shortcut]Action
+
+// DefaultKeymap returns a new keymap with the default shortcuts of the editor
+func DefaultKeymap() Keymap {
+	km := Keymap{
+		{Key: keyDelete}:                                (*ControlEditable).Delete,
+		{Key: keyBackspace}:                             (*ControlEditable).Delete,
+		{Key: keyArrowLeft}:                             nudge(-nudgeStep, 0),
+		{Key: keyArrowRight}:                            nudge(nudgeStep, 0),
+		{Key: keyArrowUp}:                               nudge(0, -nudgeStep),
+		{Key: keyArrowDown}:                             nudge(0, nudgeStep),
+		{Key: keyArrowLeft, Shift: true}:                nudge(-nudgeShiftStep, 0),
+		{Key: keyArrowRight, Shift: true}:               nudge(nudgeShiftStep, 0),
+		{Key: keyArrowUp, Shift: true}:                  nudge(0, -nudgeShiftStep),
+		{Key: keyArrowDown, Shift: true}:                nudge(0, nudgeShiftStep),
+		{Key: keyEscape}:                                (*ControlEditable).Escape,
+		{Key: 'A', Ctrl: true}:                          (*ControlEditable).SelectAll,
+		{Key: 'D', Ctrl: true}:                          (*ControlEditable).Duplicate,
+		{Key: 'Z', Ctrl: true}:                          (*ControlEditable).Undo,
+		{Key: 'Z', Ctrl: true, Shift: true}:             (*ControlEditable).Redo,
+		{Key: 'Y', Ctrl: true}:                          (*ControlEditable).Redo,
+		{Key: 'G', Ctrl: true}:                          (*ControlEditable).Group,
+		{Key: 'G', Ctrl: true, Shift: true}:             (*ControlEditable).Ungroup,
+		{Key: keyBracketRight, Ctrl: true}:              (*ControlEditable).BringForward,
+		{Key: keyBracketLeft, Ctrl: true}:               (*ControlEditable).SendBackward,
+		{Key: keyBracketRight, Ctrl: true, Shift: true}: (*ControlEditable).BringToFront,
+		{Key: keyBracketLeft, Ctrl: true, Shift: true}:  (*ControlEditable).SendToBack,
+	}
+	for _, a := range alignActions {
+		km[a.shortcut] = a.do
+	}
+	return km
+}
+
+// Bind makes the shortcut run the action, replacing its former action
+func (km Keymap) Bind(s Shortcut, a Action) {
+	km[s] = a
+}
+
+// Unbind leaves the key to the browser
+func (km Keymap) Unbind(s Shortcut) {
+	delete(km, s)
+}
+
+// keyDown runs the action bound to the key, unless the key is typed in a text field
+func (ed *ControlEditable) keyDown(e jquery.Event) {
+	if ed.typing(e) {
+		return
+	}
+	a, ok := ed.Keymap[shortcutOf(e)]
+	if !ok {
+		return
+	}
+	e.PreventDefault()
+	a(ed)
+}
+
+// typing reports whether the event comes from a text field, or the inline editor
+func (ed *ControlEditable) typing(e jquery.Event) bool {
+	return ed.Editing() || jQuery(e.Target).Is("input, textarea, select")
+}
+
+func (ed *ControlEditable) Undo() {
+	ed.History.Undo()
+}
+
+func (ed *ControlEditable) Redo() {
+	ed.History.Redo()
+}
+
+// Delete removes the selected elements
+func (ed *ControlEditable) Delete() {
+	selected := ed.doc.Selected()
+	commands := []Command{}
+	// from the top, the indices below stay valid and undo puts them back from the bottom
+	for k := len(selected) - 1; k >= 0; k-- {
+		ele := unwrap(selected[k])
+		commands = append(commands, reverseCommand{newAddCommand(ed.doc, ed.doc.scopeId(), ed.doc.IndexOf(ele.Id()), ele)})
+	}
+	if len(commands) > 0 {
+		ed.History.Do(newBatchCommand(commands...))
+	}
+}
+
+// nudge moves the selected elements by dx, dy
+func nudge(dx, dy float64) Action {
+	return func(ed *ControlEditable) {
+		ed.arrange(1, func(elements []MockupElement) {
+			for _, e := range elements {
+				shiftBy(e, dx, dy)
+			}
+		})
+	}
+}
+
+// Duplicate copies the selected elements on top of the scope, a little off, and selects the copies
+func (ed *ControlEditable) Duplicate() {
+	ed.addCopies(ed.doc.Selected(), copyOffset)
+}
+
+// SelectAll selects every element of the scope
+func (ed *ControlEditable) SelectAll() {
+	ids := []string{}
+	for _, ele := range ed.doc.Scope() {
+		ids = append(ids, ele.Id())
+	}
+	ed.doc.SelectOnly(ids...)
+}
+
+// Escape clears the selection, or leaves the entered group when nothing is selected
+func (ed *ControlEditable) Escape() {
+	if len(ed.doc.Selected()) > 0 {
+		ed.doc.ClearSelection()
+		return
+	}
+	ed.doc.Exit()
+}
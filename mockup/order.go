@@ -0,0 +1,75 @@
+//go:build js
+
+package mockup
+
+// BringForward raises every selected element above the element right above it
+func (ed *ControlEditable) BringForward() {
+	ed.reorder(func(ids []string, selected map[string]bool) {
+		for i := len(ids) - 2; i >= 0; i-- {
+			if selected[ids[i]] && !selected[ids[i+1]] {
+				ids[i], ids[i+1] = ids[i+1], ids[i]
+			}
+		}
+	})
+}
+
+// SendBackward lowers every selected element below the element right below it
+func (ed *ControlEditable) SendBackward() {
+	ed.reorder(func(ids []string, selected map[string]bool) {
+		for i := 1; i < len(ids); i++ {
+			if selected[ids[i]] && !selected[ids[i-1]] {
+				ids[i], ids[i-1] = ids[i-1], ids[i]
+			}
+		}
+	})
+}
+
+// BringToFront puts the selected elements on top, keeping their order
+func (ed *ControlEditable) BringToFront() {
+	ed.reorder(func(ids []string, selected map[string]bool) {
+		copy(ids, partition(ids, selected, false))
+	})
+}
+
+// SendToBack puts the selected elements at the bottom, keeping their order
+func (ed *ControlEditable) SendToBack() {
+	ed.reorder(func(ids []string, selected map[string]bool) {
+		copy(ids, partition(ids, selected, true))
+	})
+}
+
+// partition returns the ids of the selected elements first when first is set, else last
+func partition(ids []string, selected map[string]bool, first bool) []string {
+	sorted := []string{}
+	for _, pass := range []bool{first, !first} {
+		for _, id := range ids {
+			if selected[id] == pass {
+				sorted = append(sorted, id)
+			}
+		}
+	}
+	return sorted
+}
+
+// reorder records the change of the z-order of the scope made by arrange
+func (ed *ControlEditable) reorder(arrange func(ids []string, selected map[string]bool)) {
+	selected := map[string]bool{}
+	for _, wrapper := range ed.doc.Selected() {
+		selected[unwrap(wrapper).Id()] = true
+	}
+	if len(selected) == 0 {
+		return
+	}
+	before := []string{}
+	for _, ele := range ed.doc.Scope() {
+		before = append(before, ele.Id())
+	}
+	after := append([]string{}, before...)
+	arrange(after, selected)
+	for k := range before {
+		if before[k] != after[k] {
+			ed.History.Do(newOrderCommand(ed.doc, before, after))
+			return
+		}
+	}
+}
@@ -1,6 +1,13 @@
+//go:build js
+
 package main
 
 import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
 	"github.com/gopherjs/gopherjs/js"
 	"github.com/gopherjs/jquery"
 	"github.com/kelwang/gopherjs-mockup/mockup"
@@ -12,8 +19,13 @@ var document = js.Global.Get("document")
 var editing_class = "editing"
 var line_editing_class = "line_editing"
 
+// canvas is the area of the page where the mockup is edited
+var canvas = svg.BBox{X: 260, Y: 5, Width: 1000, Height: 800}
+
 func main() {
-	container := initPanel()
+	// elements snap to the subdivisions of the grid, nil leaves the canvas plain
+	grid := mockup.NewGrid(40, 4, "#DDD")
+	container := initPanel(grid)
 
 	label1 := mockup.NewLabel(180, 20, 400, 158, "big text a lal ha", "E1", svg.DRAGGABLE|svg.EDITABLE)
 	textbox1 := mockup.NewTextBox(160, 40, 400, 300, "textbox 1", "E2", svg.DRAGGABLE|svg.EDITABLE)
@@ -21,100 +33,207 @@ func main() {
 	box1 := mockup.NewBox(100, 100, 800, 158, "E4", svg.DRAGGABLE|svg.EDITABLE)
 	line1 := mockup.NewLine(100, 10, 800, 400, "E5")
 
-	container.Content = append(container.Content,
-		label1.Svg(),
-		textbox1.Svg(),
-		button1.Svg(),
-		box1.Svg(),
-		line1.Svg(),
-	)
+	doc := mockup.NewDocument(label1, textbox1, button1, box1, line1)
+	toolbar := mockup.NewDocument()
 
-	m := map[string]mockup.MockupElement{
-		"E1": label1,
-		"E2": textbox1,
-		"E3": button1,
-		"E4": box1,
-		"E5": line1,
+	for _, ele := range doc.Elements() {
+		container.Content = append(container.Content, ele.Svg())
 	}
 
-	container.Content = initToolBar(container, m)
+	container.Content = initToolBar(container, toolbar)
 
-	enableControl(m)
+	ed := enableControl(doc, toolbar, grid)
 	js.Global.Get("document").Call("write", container.String())
 	println("here")
+
+	if id := mockupID(); id != "" {
+		go load(doc, ed, id)
+	}
 }
 
-func enableControl(m map[string]mockup.MockupElement) {
-	jQuery(document).On(jquery.CLICK, svg.EDITABLE.JqSelector(), func(e jquery.Event) {
-		wrapEditable(e, m)
-	})
+func enableControl(doc *mockup.Document, toolbar *mockup.Document, grid *mockup.Grid) *mockup.ControlEditable {
+	doc.OnChange(render)
 
-	jQuery(document).On(jquery.CLICK, svg.LINABLE.JqSelector(), func(e jquery.Event) {
-		wrapLinable(e, m)
-	})
+	ed := mockup.NewControlEditable(canvas.X, canvas.Y, canvas.Right(), canvas.Bottom())
+	ed.Grid = grid
 
-	jQuery(document).On(jquery.CLICK, "."+editing_class, func(e jquery.Event) {
-		unwrapEditable(e, m)
-	})
+	// clicking an element selects it alone, with Shift or Ctrl it is added to or taken out of the selection
+	selectClicked := func(e jquery.Event) {
+		id := jQuery(e.CurrentTarget).Attr("id")
+		if _, ok := doc.Get(id); !ok {
+			return
+		}
+		e.StopPropagation()
+		if ignoredClick(ed, e) {
+			return
+		}
+		switch {
+		case e.ShiftKey || e.CtrlKey || e.MetaKey:
+			doc.Toggle(id)
+		case doc.IsSelected(id) && len(doc.Selected()) == 1:
+			doc.Deselect(id)
+		default:
+			doc.SelectOnly(id)
+		}
+	}
+	jQuery(document).On(jquery.CLICK, svg.EDITABLE.JQSelector(), selectClicked)
+	jQuery(document).On(jquery.CLICK, svg.LINABLE.JQSelector(), selectClicked)
+
+	deselectClicked := func(e jquery.Event) {
+		if ignoredClick(ed, e) {
+			return
+		}
+		doc.Deselect(jQuery(e.CurrentTarget).Attr("id"))
+	}
+	jQuery(document).On(jquery.CLICK, "."+editing_class, deselectClicked)
+	jQuery(document).On(jquery.CLICK, "."+line_editing_class, deselectClicked)
+
+	ed.BindEvents(doc, toolbar)
 
-	jQuery(document).On(jquery.CLICK, "."+line_editing_class, func(e jquery.Event) {
-		unwrapLinable(e, m)
+	ae := mockup.NewAttributeEditor(1310, 5, 220)
+	ae.BindEvents(ed)
+
+	at := mockup.NewAlignToolbar(canvas.X, canvas.Bottom()+10, canvas.Width)
+	at.BindEvents(ed)
+
+	// save
+	ed.Keymap.Bind(mockup.Shortcut{Key: 'S', Ctrl: true}, func(ed *mockup.ControlEditable) {
+		if id := mockupID(); id != "" {
+			go save(doc, id)
+		}
 	})
+	return ed
+}
 
-	mockup.NewControlEditable(260, 5, 1260, 805).BindEvents(m)
+// ignoredClick reports whether the click ends a drag, or is the second click
+// of a double click opening the text editor, rather than a selection
+func ignoredClick(ed *mockup.ControlEditable, e jquery.Event) bool {
+	return ed.Dragged() || e.Get("detail").Int() > 1
 }
 
-func wrapLinable(e jquery.Event, m map[string]mockup.MockupElement) {
-	id := jQuery(e.CurrentTarget).Attr("id")
-	if mockupE, ok := m[id]; ok {
-		border := mockup.NewScaleLine(mockupE)
-		m[mockup.EditablePrefix+id] = border
-		jQuery("#" + id).ReplaceWith(border.Svg().Jq())
-		jQuery("#" + mockup.EditablePrefix + id).AddClass(line_editing_class)
-	}
+var apiPath = "/api/mockups/"
+
+// etag of the saved mockup the page was loaded from
+var etag = ""
+
+// mockupID is the id of the mockup being edited, taken from the page url, e.g. /#home-page
+func mockupID() string {
+	return strings.TrimPrefix(js.Global.Get("location").Get("hash").String(), "#")
 }
 
-func unwrapLinable(e jquery.Event, m map[string]mockup.MockupElement) {
-	id := jQuery(e.CurrentTarget).Attr("id")
-	if border, ok := m[id]; ok {
-		jQuery("#" + id).ReplaceWith(border.(*mockup.ScaleLine).Line().Svg().Jq())
-		delete(m, id)
+func load(doc *mockup.Document, ed *mockup.ControlEditable, id string) {
+	resp, err := http.Get(apiPath + id)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		println("cannot load mockup", id, resp.Status)
+		return
 	}
+	if err := doc.UnmarshalJSON(data); err != nil {
+		println(err.Error())
+		return
+	}
+	etag = resp.Header.Get("ETag")
+	ed.History = mockup.NewHistory()
 }
 
-func wrapEditable(e jquery.Event, m map[string]mockup.MockupElement) {
-	//container := jQuery("svg")
-	id := jQuery(e.CurrentTarget).Attr("id")
-	if mockupE, ok := m[id]; ok {
-		border1 := mockup.NewScaleBox(mockupE)
-		m[mockup.EditablePrefix+id] = border1
-		jQuery("#" + id).ReplaceWith(border1.Svg().Jq())
-		jQuery("#" + mockup.EditablePrefix + id).AddClass(editing_class)
+func save(doc *mockup.Document, id string) {
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	req, err := http.NewRequest(http.MethodPut, apiPath+id, bytes.NewReader(data))
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	} else {
+		req.Header.Set("If-None-Match", "*")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusCreated, http.StatusNoContent:
+		etag = resp.Header.Get("ETag")
+	case http.StatusPreconditionFailed:
+		js.Global.Call("alert", "This mockup was changed in another window, reload the page before saving.")
+	default:
+		println("cannot save mockup", id, resp.Status)
 	}
 }
 
-func unwrapEditable(e jquery.Event, m map[string]mockup.MockupElement) {
+// render keeps the page in sync with the document
+func render(c mockup.Change) {
+	switch c.Kind {
+	case mockup.ADDED:
+		place(c, svg.JQ(c.Element.Svg()))
+	case mockup.MOVED:
+		place(c, jQuery("#"+c.Element.Id()))
+	case mockup.REMOVED:
+		jQuery("#" + c.Element.Id()).Remove()
+	case mockup.REPLACED, mockup.DESELECTED:
+		jQuery("#" + c.Old.Id()).ReplaceWith(svg.JQ(c.Element.Svg()))
+	case mockup.SELECTED:
+		jQuery("#" + c.Old.Id()).ReplaceWith(svg.JQ(c.Element.Svg()))
+		if _, ok := c.Element.(*mockup.ScaleLine); ok {
+			jQuery("#" + c.Element.Id()).AddClass(line_editing_class)
+		} else {
+			jQuery("#" + c.Element.Id()).AddClass(editing_class)
+		}
+	case mockup.REFRAMED:
+		if c.Old != nil {
+			jQuery("#" + c.Old.Id()).Remove()
+		}
+		if c.Element != nil {
+			jQuery("svg").Append(svg.JQ(c.Element.Svg()))
+		}
+	}
+}
 
-	//container := jQuery("svg")
-	id := jQuery(e.CurrentTarget).Attr("id")
-	if border, ok := m[id]; ok {
-		jQuery("#" + id).ReplaceWith(border.(*mockup.ScaleBox).MockupElement.Svg().Jq())
-		delete(m, id)
+// place puts the element under the one above it in the z-order, or on top of its parent.
+// The frame of a multiple selection stays over the content.
+func place(c mockup.Change, ele jquery.JQuery) {
+	if c.Above != nil {
+		jQuery("#" + c.Above.Id()).Before(ele)
+		return
+	}
+	parent := jQuery("svg")
+	if c.Parent != nil {
+		parent = jQuery("#" + c.Parent.Id())
+	}
+	parent.Append(ele)
+	if box := jQuery("#" + mockup.SelectionBoxId); box.Length > 0 {
+		jQuery("svg").Append(box)
 	}
 }
 
-func initToolBar(container svg.Svg, m map[string]mockup.MockupElement) []svg.SvgElement {
+func initToolBar(container svg.Svg, toolbar *mockup.Document) []svg.SvgElement {
 	textboxTool := mockup.NewTextBox(60, 20, 30, 20, "textbox", "T1", svg.CLONABLE)
 	buttonTool := mockup.NewButton(60, 20, 150, 20, "button", "T2", svg.CLONABLE)
 	boxTool := mockup.NewBox(60, 60, 30, 60, "T3", svg.CLONABLE)
 	labelTool := mockup.NewLabel(60, 20, 160, 90, "label", "T4", svg.CLONABLE)
 	lineTool := mockup.NewLine(60, 0, 30, 160, "T5")
 
-	m["T1"] = textboxTool
-	m["T2"] = buttonTool
-	m["T3"] = boxTool
-	m["T4"] = labelTool
-	m["T5"] = lineTool
+	toolbar.Add(textboxTool)
+	toolbar.Add(buttonTool)
+	toolbar.Add(boxTool)
+	toolbar.Add(labelTool)
+	toolbar.Add(lineTool)
 
 	return append(container.Content,
 		textboxTool.Svg(),
@@ -125,8 +244,8 @@ func initToolBar(container svg.Svg, m map[string]mockup.MockupElement) []svg.Svg
 	)
 }
 
-func initPanel() svg.Svg {
-	return svg.Svg{
+func initPanel(grid *mockup.Grid) svg.Svg {
+	panel := svg.Svg{
 		Width:  1300,
 		Height: 800,
 		Content: []svg.SvgElement{
@@ -140,12 +259,17 @@ func initPanel() svg.Svg {
 			},
 			//main convas
 			&svg.Rect{
-				Width:    1000,
-				Height:   800,
-				X:        260,
-				Y:        5,
+				Width:    canvas.Width,
+				Height:   canvas.Height,
+				X:        canvas.X,
+				Y:        canvas.Y,
 				Fillable: svg.NewFillable("#F1F1F1", 1),
+				Editable: svg.SELECTION_AREA,
 			},
 		},
 	}
+	if grid != nil {
+		panel.Content = append(panel.Content, grid.Svg(canvas, "grid"))
+	}
+	return panel
 }
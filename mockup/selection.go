@@ -0,0 +1,186 @@
+package mockup
+
+import (
+	"github.com/kelwang/gopherjs-mockup/mockup/svg"
+)
+
+// SelectBox outlines an element of a multiple selection, the selection is edited through its SelectionBox
+type SelectBox struct {
+	idable
+	MockupElement
+}
+
+func NewSelectBox(mockupElement MockupElement) *SelectBox {
+	return &SelectBox{
+		idable:        idable{id: EditablePrefix + mockupElement.Id()},
+		MockupElement: mockupElement,
+	}
+}
+
+func (ele *SelectBox) Id() string {
+	return ele.idable.id
+}
+
+func (ele *SelectBox) SetId(id string) {
+	ele.idable.SetId(id)
+}
+
+func (ele *SelectBox) MoveTo(x, y float64) {
+	ele.MockupElement.MoveTo(x, y)
+	ele.layout()
+}
+
+func (ele *SelectBox) ResizeTo(x, y, w, h float64) {
+	ele.MockupElement.ResizeTo(x, y, w, h)
+	ele.layout()
+}
+
+func (ele *SelectBox) RotateTo(angle float64) {
+	ele.MockupElement.RotateTo(angle)
+	ele.layout()
+}
+
+func (ele *SelectBox) Svg() svg.SvgElement {
+	return &svg.Group{
+		Content: []svg.SvgElement{
+			ele.MockupElement.Svg(),
+			outline(ele.MockupElement, ele.idable.id),
+		},
+		Editable: svg.DRAGGABLE,
+		IDAble: svg.IDAble{
+			ID: ele.idable.id,
+		},
+	}
+}
+
+// layout updates the outline on the page to the geometry of the element
+func (ele *SelectBox) layout() {
+	o := outline(ele.MockupElement, ele.idable.id)
+	o.SetTransform(o.Transform)
+	r := o.Content[0].(*svg.Rect)
+	r.MoveTo(r.X, r.Y)
+	r.ResizeTo(r.Width, r.Height)
+}
+
+// outline is the dashed frame of an element, it turns along with the element
+func outline(ele MockupElement, id string) *svg.Group {
+	base := ele.GetBase()
+	w, h, _, _ := base.GetWHXY()
+	b := svg.BBox{Width: w, Height: h}
+	t := base.transformable()
+	if _, ok := ele.(*line); ok {
+		// a line is drawn where it is, without transform
+		b = ele.Svg().BBox()
+		t = svg.Transformable{}
+	}
+	return &svg.Group{
+		Content:       []svg.SvgElement{frameRect(b, "olr_"+id)},
+		Transformable: t,
+		IDAble:        svg.IDAble{ID: "out_" + id},
+	}
+}
+
+// frameRect is a dashed rectangle, only its stroke catches the mouse
+func frameRect(b svg.BBox, id string) *svg.Rect {
+	return &svg.Rect{
+		X:        b.X,
+		Y:        b.Y,
+		Width:    b.Width,
+		Height:   b.Height,
+		Fillable: svg.NewFillable("none", 1),
+		Strokeable: svg.Strokeable{
+			Stroke:          DARKGREY,
+			StrokeWidth:     stroke_width / 2,
+			StrokeDashArray: []float64{4, 4},
+		},
+		IDAble: svg.IDAble{ID: id},
+	}
+}
+
+// SelectionBoxId is the id of the box framing a multiple selection
+var SelectionBoxId = EditablePrefix + "selection"
+
+// SelectionBox frames the elements of a multiple selection. Moving it moves them all,
+// resizing it scales their positions and sizes in proportion.
+type SelectionBox struct {
+	idable
+	editable
+	elements []MockupElement
+}
+
+// NewSelectionBox frames the selection wrappers of the elements
+func NewSelectionBox(elements []MockupElement) *SelectionBox {
+	return &SelectionBox{
+		idable:   idable{id: SelectionBoxId},
+		elements: elements,
+	}
+}
+
+// Elements returns the selection wrappers framed by the box
+func (ele *SelectionBox) Elements() []MockupElement {
+	return append([]MockupElement{}, ele.elements...)
+}
+
+func (ele *SelectionBox) frame() svg.BBox {
+	return frameOf(ele.elements)
+}
+
+func (ele *SelectionBox) GetWHXY() (float64, float64, float64, float64) {
+	b := ele.frame()
+	return b.Width, b.Height, b.X, b.Y
+}
+
+func (ele *SelectionBox) GetBase() BaseElement {
+	return newBaseElement(ele.GetWHXY())
+}
+
+func (ele *SelectionBox) MoveTo(x, y float64) {
+	moveAll(ele.elements, x, y)
+	ele.layout()
+}
+
+func (ele *SelectionBox) ResizeTo(x, y, w, h float64) {
+	scaleAll(ele.elements, x, y, w, h)
+	ele.layout()
+}
+
+// RotateTo does nothing, the elements of a multiple selection are turned one by one
+func (ele *SelectionBox) RotateTo(angle float64) {
+}
+
+// ResizeHandleTo drags the resize square sqr to x, y while the opposite corner or edge stays in place
+func (ele *SelectionBox) ResizeHandleTo(sqr int, x, y float64) {
+	if sqr < 1 || sqr > 8 {
+		return
+	}
+	b := ele.frame()
+	left, top, right, bottom := dragEdges(sqr, b.X, b.Y, b.Right(), b.Bottom(), svg.NewPoint(x, y))
+	ele.ResizeTo(left, top, right-left, bottom-top)
+}
+
+// Svg draws the frame with its resize squares, the elements are drawn by their wrappers
+func (ele *SelectionBox) Svg() svg.SvgElement {
+	b := ele.frame()
+	content := []svg.SvgElement{frameRect(b, "frm_"+ele.idable.id)}
+	for _, hd := range handles(b.Width, b.Height) {
+		if hd.editable == svg.ROTATABLE {
+			continue
+		}
+		content = append(content, scaleboxRect(b.X+hd.x-square_height/2, b.Y+hd.y-square_height/2, stroke_width, square_height, hd.prefix+ele.idable.id, hd.editable))
+	}
+	return &svg.Group{
+		Content: content,
+		IDAble: svg.IDAble{
+			ID: ele.idable.id,
+		},
+	}
+}
+
+// layout updates the frame and its squares on the page
+func (ele *SelectionBox) layout() {
+	for _, se := range ele.Svg().(*svg.Group).Content {
+		r := se.(*svg.Rect)
+		r.MoveTo(r.X, r.Y)
+		r.ResizeTo(r.Width, r.Height)
+	}
+}
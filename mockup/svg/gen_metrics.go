@@ -0,0 +1,128 @@
+//go:build ignore
+
+// gen_metrics writes gofont_metrics.go, the advances of the Go fonts used by GoFontMeasurer.
+//
+//	go run gen_metrics.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// the runes measured, Basic Latin to Latin Extended-B
+const (
+	firstRune = 0x20
+	lastRune  = 0x24f
+)
+
+// styles are the fonts in the order of the go* style constants
+var styles = []struct {
+	name string
+	ttf  []byte
+}{
+	{"goRegular", goregular.TTF},
+	{"goBold", gobold.TTF},
+	{"goItalic", goitalic.TTF},
+	{"goBoldItalic", gobolditalic.TTF},
+}
+
+func main() {
+	var buf sfnt.Buffer
+	b := &bytes.Buffer{}
+	fmt.Fprintln(b, "// Code generated from the Go fonts of golang.org/x/image/font/gofont; DO NOT EDIT.")
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, "package svg")
+
+	advances := make([][]int, len(styles))
+	for k, s := range styles {
+		f, err := sfnt.Parse(s.ttf)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// the advances are in font units, read at one unit per pixel
+		upem := fixed.I(int(f.UnitsPerEm()))
+		if k == 0 {
+			m, err := f.Metrics(&buf, upem, font.HintingNone)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Fprintln(b)
+			fmt.Fprintln(b, "// metrics of the Go fonts, in font units")
+			fmt.Fprintln(b, "const (")
+			fmt.Fprintf(b, "goFontUnitsPerEm = %d\n", f.UnitsPerEm())
+			fmt.Fprintf(b, "goFontAscent = %d\n", m.Ascent.Round())
+			fmt.Fprintf(b, "goFontDescent = %d\n", m.Descent.Round())
+			fmt.Fprintln(b, ")")
+		}
+		for r := rune(firstRune); r <= lastRune; r++ {
+			advances[k] = append(advances[k], advance(f, &buf, r, upem))
+		}
+	}
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, "// styles of the Go fonts, in the order of goFontAdvances")
+	fmt.Fprintln(b, "const (")
+	for k, s := range styles {
+		if k == 0 {
+			fmt.Fprintf(b, "%s = iota\n", s.name)
+			continue
+		}
+		fmt.Fprintln(b, s.name)
+	}
+	fmt.Fprintln(b, ")")
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, "// goFontFirstRune is the rune of the first advance of each style")
+	fmt.Fprintf(b, "const goFontFirstRune = %#x\n", firstRune)
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, "// goFontAdvances are the advance widths of Basic Latin to Latin Extended-B, 0 for a missing glyph")
+	fmt.Fprintf(b, "var goFontAdvances = [...][%d]uint16{\n", lastRune-firstRune+1)
+	for k, s := range styles {
+		fmt.Fprintf(b, "%s: {", s.name)
+		for i, a := range advances[k] {
+			if i%16 == 0 {
+				fmt.Fprintln(b)
+			} else {
+				fmt.Fprint(b, " ")
+			}
+			fmt.Fprintf(b, "%d,", a)
+		}
+		fmt.Fprintln(b, "\n},")
+	}
+	fmt.Fprintln(b, "}")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("gofont_metrics.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// advance is the advance of the rune in font units, 0 when the font has no glyph for it
+func advance(f *sfnt.Font, buf *sfnt.Buffer, r rune, upem fixed.Int26_6) int {
+	x, err := f.GlyphIndex(buf, r)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if x == 0 {
+		return 0
+	}
+	a, err := f.GlyphAdvance(buf, x, upem, font.HintingNone)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return a.Round()
+}
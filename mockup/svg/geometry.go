@@ -0,0 +1,235 @@
+package svg
+
+import (
+	"math"
+)
+
+// BBox is an axis aligned bounding box in user units
+type BBox struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+func NewBBox(x1, y1, x2, y2 float64) BBox {
+	return BBox{
+		X:      math.Min(x1, x2),
+		Y:      math.Min(y1, y2),
+		Width:  math.Abs(x2 - x1),
+		Height: math.Abs(y2 - y1),
+	}
+}
+
+func (b BBox) Right() float64 {
+	return b.X + b.Width
+}
+
+func (b BBox) Bottom() float64 {
+	return b.Y + b.Height
+}
+
+func (b BBox) Center() Point {
+	return Point{x: b.X + b.Width/2, y: b.Y + b.Height/2}
+}
+
+// Contains reports whether x, y is inside the box or on its edge
+func (b BBox) Contains(x, y float64) bool {
+	return x >= b.X && x <= b.Right() && y >= b.Y && y <= b.Bottom()
+}
+
+// ContainsBBox reports whether o lies entirely inside the box
+func (b BBox) ContainsBBox(o BBox) bool {
+	return o.X >= b.X && o.Right() <= b.Right() && o.Y >= b.Y && o.Bottom() <= b.Bottom()
+}
+
+// Intersects reports whether the boxes overlap, boxes sharing an edge intersect
+func (b BBox) Intersects(o BBox) bool {
+	return b.X <= o.Right() && o.X <= b.Right() && b.Y <= o.Bottom() && o.Y <= b.Bottom()
+}
+
+// Intersect returns the overlap of the boxes, it reports false when they don't intersect
+func (b BBox) Intersect(o BBox) (BBox, bool) {
+	if !b.Intersects(o) {
+		return BBox{}, false
+	}
+	return NewBBox(math.Max(b.X, o.X), math.Max(b.Y, o.Y), math.Min(b.Right(), o.Right()), math.Min(b.Bottom(), o.Bottom())), true
+}
+
+// Union returns the smallest box containing both boxes
+func (b BBox) Union(o BBox) BBox {
+	return NewBBox(math.Min(b.X, o.X), math.Min(b.Y, o.Y), math.Max(b.Right(), o.Right()), math.Max(b.Bottom(), o.Bottom()))
+}
+
+// BBox is the bounding box of the points, the zero BBox when there are none
+func (p Points) BBox() BBox {
+	if len(p) == 0 {
+		return BBox{}
+	}
+	x1, y1, x2, y2 := p[0].x, p[0].y, p[0].x, p[0].y
+	for _, pt := range p[1:] {
+		x1, y1 = math.Min(x1, pt.x), math.Min(y1, pt.y)
+		x2, y2 = math.Max(x2, pt.x), math.Max(y2, pt.y)
+	}
+	return NewBBox(x1, y1, x2, y2)
+}
+
+func (se *Rect) BBox() BBox {
+	return se.Transformable.bbox(BBox{X: se.X, Y: se.Y, Width: se.Width, Height: se.Height})
+}
+
+func (se *Circle) BBox() BBox {
+	return se.Transformable.bbox(NewBBox(se.X-se.R, se.Y-se.R, se.X+se.R, se.Y+se.R))
+}
+
+func (se *Ellipse) BBox() BBox {
+	return se.Transformable.bbox(NewBBox(se.X-se.RX, se.Y-se.RY, se.X+se.RX, se.Y+se.RY))
+}
+
+func (se *Line) BBox() BBox {
+	return se.Transformable.bbox(NewBBox(se.X1, se.Y1, se.X2, se.Y2))
+}
+
+func (se *Polygon) BBox() BBox {
+	return se.Transformable.bbox(se.Points.BBox())
+}
+
+func (se *Polyline) BBox() BBox {
+	return se.Transformable.bbox(se.Points.BBox())
+}
+
+func (se *Path) BBox() BBox {
+	return se.Transformable.bbox(se.D.BBox())
+}
+
+// BBox spans the lines of the text from the top of their ascent to the bottom of their descent, as measured by MeasureText
+func (se *Text) BBox() BBox {
+	if len(se.Spans) == 0 {
+		return se.Transformable.bbox(se.lineBBox(se.Content, se.X, se.Y))
+	}
+	b := se.lineBBox(se.Spans[0].Content, se.Spans[0].X, se.Spans[0].Y)
+	if se.Content != "" {
+		b = b.Union(se.lineBBox(se.Content, se.X, se.Y))
+	}
+	for _, span := range se.Spans[1:] {
+		b = b.Union(se.lineBBox(span.Content, span.X, span.Y))
+	}
+	return se.Transformable.bbox(b)
+}
+
+// lineBBox is the box of a line of the text starting at x, y
+func (se *Text) lineBBox(s string, x, y float64) BBox {
+	m := MeasureText(s, se.Font())
+	switch se.TextAnchor {
+	case MIDDLE:
+		x -= m.Width / 2
+	case END:
+		x -= m.Width
+	}
+	return BBox{X: x, Y: y - m.Ascent, Width: m.Width, Height: m.Height()}
+}
+
+// BBox is the union of the children's boxes, in the coordinates of the group's parent
+func (se *Group) BBox() BBox {
+	if len(se.Content) == 0 {
+		return se.Transformable.bbox(BBox{})
+	}
+	b := se.Content[0].BBox()
+	for _, v := range se.Content[1:] {
+		b = b.Union(v.BBox())
+	}
+	return se.Transformable.bbox(b)
+}
+
+// BBox is the exact bounding box of the path, including the extrema of its curves
+func (ps PathItems) BBox() BBox {
+	pts := Points{}
+	for _, seg := range ps.segments() {
+		end := seg.points[len(seg.points)-1]
+		switch seg.action {
+		case CURVETO:
+			pts = append(pts, cubicExtrema(seg.from, seg.points[0], seg.points[1], end)...)
+		case QUADRATIC_BEZIER_CURVE:
+			pts = append(pts, quadExtrema(seg.from, seg.points[0], end)...)
+		case ELLIPTICAL_ARC:
+			pts = append(pts, arcExtrema(seg.from, end, seg.arc)...)
+		}
+		pts = append(pts, end)
+	}
+	return pts.BBox()
+}
+
+// cubicExtrema are the points of the curve where it turns horizontally or vertically
+func cubicExtrema(p0, p1, p2, p3 Point) []Point {
+	pts := []Point{}
+	axes := [][4]float64{{p0.x, p1.x, p2.x, p3.x}, {p0.y, p1.y, p2.y, p3.y}}
+	for _, v := range axes {
+		// roots of the derivative a*t^2 + b*t + c
+		a := -v[0] + 3*v[1] - 3*v[2] + v[3]
+		b := 2 * (v[0] - 2*v[1] + v[2])
+		c := v[1] - v[0]
+		for _, t := range quadraticRoots(a, b, c) {
+			if t > 0 && t < 1 {
+				pts = append(pts, cubicAt(p0, p1, p2, p3, t))
+			}
+		}
+	}
+	return pts
+}
+
+func quadExtrema(p0, p1, p2 Point) []Point {
+	pts := []Point{}
+	axes := [][3]float64{{p0.x, p1.x, p2.x}, {p0.y, p1.y, p2.y}}
+	for _, v := range axes {
+		d := v[0] - 2*v[1] + v[2]
+		if d == 0 {
+			continue
+		}
+		if t := (v[0] - v[1]) / d; t > 0 && t < 1 {
+			pts = append(pts, quadAt(p0, p1, p2, t))
+		}
+	}
+	return pts
+}
+
+func quadraticRoots(a, b, c float64) []float64 {
+	const eps = 1e-12
+	if math.Abs(a) < eps {
+		if math.Abs(b) < eps {
+			return nil
+		}
+		return []float64{-c / b}
+	}
+	d := b*b - 4*a*c
+	if d < 0 {
+		return nil
+	}
+	sq := math.Sqrt(d)
+	return []float64{(-b + sq) / (2 * a), (-b - sq) / (2 * a)}
+}
+
+// arcExtrema are the points of the arc where the ellipse turns horizontally or vertically
+func arcExtrema(p1, p2 Point, arc Arc) []Point {
+	c, rx, ry, phi, theta, delta, ok := arcCenter(p1, p2, arc)
+	if !ok {
+		return nil
+	}
+	sin, cos := math.Sin(phi), math.Cos(phi)
+	tx := math.Atan2(-ry*sin, rx*cos)
+	ty := math.Atan2(ry*cos, rx*sin)
+	pts := []Point{}
+	for _, t := range []float64{tx, tx + math.Pi, ty, ty + math.Pi} {
+		// distance from the start angle, in the direction of the sweep
+		d := math.Mod(t-theta, 2*math.Pi)
+		if delta < 0 {
+			d = -d
+		}
+		if d < 0 {
+			d += 2 * math.Pi
+		}
+		if d <= math.Abs(delta) {
+			pts = append(pts, ellipseAt(c, rx, ry, phi, t))
+		}
+	}
+	return pts
+}
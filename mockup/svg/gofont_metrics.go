@@ -0,0 +1,173 @@
+// Code generated from the Go fonts of golang.org/x/image/font/gofont; DO NOT EDIT.
+
+package svg
+
+// metrics of the Go fonts, in font units
+const (
+	goFontUnitsPerEm = 2048
+	goFontAscent     = 1935
+	goFontDescent    = 432
+)
+
+// styles of the Go fonts, in the order of goFontAdvances
+const (
+	goRegular = iota
+	goBold
+	goItalic
+	goBoldItalic
+)
+
+// goFontFirstRune is the rune of the first advance of each style
+const goFontFirstRune = 0x20
+
+// goFontAdvances are the advance widths of Basic Latin to Latin Extended-B, 0 for a missing glyph
+var goFontAdvances = [...][560]uint16{
+	goRegular: {
+		569, 569, 727, 1139, 1139, 1821, 1366, 391, 682, 682, 1196, 1196, 648, 1196, 648, 569,
+		1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 627, 627, 1196, 1196, 1196, 1139,
+		2079, 1366, 1366, 1479, 1479, 1366, 1251, 1593, 1479, 817, 1015, 1366, 1139, 1706, 1479, 1593,
+		1366, 1593, 1479, 1366, 1251, 1479, 1366, 1933, 1366, 1366, 1251, 569, 569, 569, 960, 1139,
+		682, 1139, 1139, 1024, 1139, 1139, 569, 1139, 1139, 505, 519, 1024, 548, 1706, 1139, 1139,
+		1139, 1139, 682, 1024, 579, 1139, 1024, 1479, 1024, 1024, 1024, 684, 532, 684, 1196, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		569, 682, 1139, 1139, 1139, 1139, 532, 1139, 682, 1509, 758, 1139, 1196, 682, 1509, 1139,
+		819, 1196, 933, 933, 682, 1139, 1100, 547, 682, 933, 748, 1139, 1708, 1708, 1708, 1251,
+		1366, 1366, 1366, 1366, 1366, 1366, 2048, 1479, 1366, 1366, 1366, 1366, 817, 817, 817, 817,
+		1489, 1479, 1593, 1593, 1593, 1593, 1593, 1196, 1593, 1479, 1479, 1479, 1479, 1366, 1366, 1251,
+		1139, 1139, 1139, 1139, 1139, 1139, 1821, 1024, 1139, 1139, 1139, 1139, 505, 505, 505, 505,
+		1139, 1139, 1139, 1139, 1139, 1139, 1139, 1196, 1251, 1139, 1139, 1139, 1139, 1024, 1139, 1024,
+		1371, 1153, 1371, 1153, 1366, 1139, 1479, 1024, 1479, 1024, 1479, 1024, 1479, 1024, 1479, 1332,
+		1489, 1139, 1366, 1139, 1366, 1139, 1366, 1139, 1366, 1139, 1366, 1139, 1593, 1139, 1593, 1139,
+		1593, 1139, 1593, 1139, 1479, 1139, 1479, 1139, 817, 505, 817, 505, 817, 505, 817, 505,
+		817, 505, 1646, 953, 1024, 519, 1366, 1024, 1024, 1139, 548, 1139, 548, 1139, 674, 1139,
+		700, 1139, 592, 1479, 1139, 1479, 1139, 1479, 1139, 1237, 1479, 1139, 1593, 1139, 1593, 1139,
+		1593, 1139, 2048, 1933, 1479, 682, 1479, 682, 1479, 682, 1366, 1024, 1366, 1024, 1366, 1024,
+		1366, 1024, 1251, 569, 1251, 768, 1251, 569, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1139,
+		1479, 1139, 1479, 1139, 1933, 1479, 1366, 1024, 1366, 1251, 1024, 1251, 1024, 1251, 1024, 455,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 1139, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1366, 1139, 817,
+		505, 1593, 1139, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1139, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1366, 1139, 2048, 1821, 1593, 1251,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 1366, 1024, 1251, 569, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	},
+	goBold: {
+		569, 682, 971, 1139, 1139, 1821, 1479, 487, 682, 682, 1143, 1196, 569, 1196, 569, 569,
+		1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 682, 682, 1196, 1196, 1196, 1251,
+		1997, 1479, 1479, 1479, 1479, 1366, 1251, 1593, 1479, 928, 1139, 1479, 1251, 1706, 1479, 1593,
+		1366, 1593, 1479, 1366, 1251, 1479, 1366, 1933, 1366, 1366, 1251, 682, 569, 682, 1196, 1139,
+		682, 1139, 1251, 1139, 1251, 1139, 682, 1251, 1251, 592, 589, 1139, 611, 1821, 1251, 1251,
+		1251, 1251, 797, 1139, 682, 1251, 1139, 1593, 1139, 1139, 1024, 797, 573, 797, 1196, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		569, 682, 1139, 1139, 1139, 1139, 573, 1139, 682, 1509, 758, 1139, 1196, 682, 1509, 1139,
+		819, 1196, 1013, 1013, 682, 1251, 1139, 568, 682, 1013, 748, 1139, 1708, 1708, 1708, 1251,
+		1479, 1479, 1479, 1479, 1479, 1479, 2048, 1479, 1366, 1366, 1366, 1366, 928, 928, 928, 928,
+		1479, 1479, 1593, 1593, 1593, 1593, 1593, 1196, 1593, 1479, 1479, 1479, 1479, 1366, 1366, 1251,
+		1139, 1139, 1139, 1139, 1139, 1139, 1821, 1139, 1139, 1139, 1139, 1139, 592, 592, 592, 592,
+		1251, 1251, 1251, 1251, 1251, 1251, 1251, 1196, 1251, 1251, 1251, 1251, 1251, 1139, 1251, 1139,
+		1479, 1139, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1472,
+		1479, 1251, 1366, 1139, 1366, 1139, 1366, 1139, 1366, 1139, 1366, 1139, 1593, 1251, 1593, 1251,
+		1593, 1251, 1593, 1251, 1479, 1251, 1479, 1251, 928, 592, 928, 592, 928, 592, 928, 592,
+		928, 592, 1787, 1149, 1139, 579, 1479, 1139, 1139, 1251, 611, 1251, 611, 1251, 833, 1251,
+		981, 1251, 645, 1479, 1251, 1479, 1251, 1479, 1251, 1451, 1479, 1251, 1593, 1251, 1593, 1251,
+		1593, 1251, 2048, 1933, 1479, 797, 1479, 797, 1479, 797, 1366, 1139, 1366, 1139, 1366, 1139,
+		1366, 1139, 1251, 682, 1251, 981, 1251, 682, 1479, 1251, 1479, 1251, 1479, 1251, 1479, 1251,
+		1479, 1251, 1479, 1251, 1933, 1593, 1366, 1139, 1366, 1251, 1024, 1251, 1024, 1251, 1024, 629,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 1139, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1479, 1139, 928,
+		592, 1593, 1251, 1479, 1251, 1479, 1251, 1479, 1251, 1479, 1251, 1479, 1251, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1479, 1139, 2048, 1821, 1593, 1251,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 1366, 1139, 1251, 682, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	},
+	goItalic: {
+		569, 591, 749, 1161, 1161, 1843, 1388, 413, 704, 704, 1218, 1218, 670, 1218, 670, 591,
+		1161, 1161, 1161, 1161, 1161, 1161, 1161, 1161, 1161, 1161, 649, 649, 1218, 1218, 1218, 1161,
+		2101, 1388, 1388, 1501, 1501, 1388, 1273, 1615, 1501, 839, 1037, 1388, 1161, 1728, 1501, 1615,
+		1388, 1615, 1501, 1388, 1273, 1501, 1388, 1955, 1388, 1388, 1273, 591, 591, 591, 982, 1161,
+		704, 1161, 1161, 1046, 1161, 1161, 591, 1161, 1161, 527, 541, 1046, 570, 1728, 1161, 1161,
+		1161, 1161, 704, 1046, 601, 1161, 1046, 1501, 1046, 1046, 1046, 706, 554, 706, 1218, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		569, 704, 1161, 1161, 1161, 1161, 554, 1161, 704, 1531, 780, 1161, 1218, 704, 1531, 1161,
+		841, 1218, 961, 961, 704, 1161, 1122, 569, 704, 961, 770, 1161, 1730, 1730, 1730, 1273,
+		1388, 1388, 1388, 1388, 1388, 1366, 2070, 1501, 1388, 1388, 1388, 1388, 839, 839, 839, 839,
+		1511, 1501, 1615, 1615, 1615, 1615, 1615, 1196, 1615, 1501, 1501, 1501, 1501, 1388, 1388, 1273,
+		1161, 1161, 1161, 1161, 1161, 1161, 1821, 1046, 1161, 1161, 1161, 1161, 527, 527, 527, 527,
+		1161, 1161, 1161, 1161, 1161, 1161, 1161, 1218, 1273, 1161, 1161, 1161, 1161, 1046, 1161, 1046,
+		1393, 1175, 1393, 1175, 1388, 1161, 1501, 1046, 1501, 1046, 1501, 1046, 1501, 1046, 1501, 1354,
+		1511, 1161, 1388, 1161, 1388, 1161, 1388, 1161, 1388, 1161, 1388, 1161, 1615, 1161, 1615, 1161,
+		1615, 1161, 1615, 1161, 1501, 1161, 1501, 1161, 839, 527, 839, 527, 839, 527, 839, 527,
+		839, 527, 1668, 975, 1046, 541, 1388, 1046, 1046, 1161, 570, 1161, 570, 1161, 696, 1161,
+		722, 1161, 614, 1501, 1161, 1501, 1161, 1501, 1161, 1259, 1501, 1161, 1615, 1161, 1615, 1161,
+		1615, 1161, 2070, 1955, 1501, 704, 1501, 704, 1501, 704, 1388, 1046, 1388, 1046, 1388, 1046,
+		1388, 1046, 1273, 591, 1273, 790, 1273, 591, 1501, 1161, 1501, 1161, 1501, 1161, 1501, 1161,
+		1501, 1161, 1501, 1161, 1955, 1501, 1388, 1046, 1388, 1273, 1046, 1273, 1046, 1273, 1046, 477,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 1161, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1388, 1161, 839,
+		527, 1615, 1161, 1501, 1161, 1501, 1161, 1501, 1161, 1501, 1161, 1501, 1161, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1388, 1161, 2070, 1843, 1615, 1273,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 1388, 1046, 1273, 591, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	},
+	goBoldItalic: {
+		569, 682, 971, 1139, 1139, 1821, 1479, 487, 682, 682, 1143, 1196, 569, 1196, 569, 569,
+		1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 1139, 682, 682, 1196, 1196, 1196, 1251,
+		1997, 1479, 1479, 1479, 1479, 1366, 1251, 1593, 1479, 928, 1139, 1479, 1251, 1706, 1479, 1593,
+		1366, 1593, 1479, 1366, 1251, 1479, 1366, 1933, 1366, 1366, 1251, 682, 569, 682, 1196, 1139,
+		682, 1251, 1251, 1139, 1251, 1139, 682, 1251, 1251, 592, 589, 1139, 611, 1821, 1251, 1251,
+		1251, 1251, 797, 1139, 682, 1251, 1139, 1593, 1139, 1139, 1024, 797, 573, 797, 1196, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		569, 682, 1139, 1139, 1139, 1139, 573, 1139, 682, 1509, 758, 1139, 1196, 682, 1509, 1139,
+		819, 1196, 1013, 1013, 682, 1251, 1139, 568, 682, 1013, 748, 1139, 1708, 1708, 1708, 1251,
+		1479, 1479, 1479, 1479, 1479, 1479, 2048, 1479, 1366, 1366, 1366, 1366, 928, 928, 928, 928,
+		1479, 1479, 1593, 1593, 1593, 1593, 1593, 1196, 1593, 1479, 1479, 1479, 1479, 1366, 1366, 1251,
+		1251, 1251, 1251, 1251, 1251, 1251, 1821, 1139, 1139, 1139, 1139, 1139, 592, 592, 592, 592,
+		1251, 1251, 1251, 1251, 1251, 1251, 1251, 1196, 1251, 1251, 1251, 1251, 1251, 1139, 1251, 1139,
+		1479, 1251, 1479, 1251, 1479, 1251, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1139, 1479, 1472,
+		1479, 1251, 1366, 1139, 1366, 1139, 1366, 1139, 1366, 1139, 1366, 1139, 1593, 1251, 1593, 1251,
+		1593, 1251, 1593, 1251, 1479, 1251, 1479, 1251, 928, 592, 928, 592, 928, 592, 928, 592,
+		928, 592, 1787, 1149, 1139, 579, 1479, 1139, 1139, 1251, 611, 1251, 611, 1251, 833, 1251,
+		981, 1251, 645, 1479, 1251, 1479, 1251, 1479, 1251, 1451, 1479, 1251, 1593, 1251, 1593, 1251,
+		1593, 1251, 2048, 1933, 1479, 797, 1479, 797, 1479, 797, 1366, 1139, 1366, 1139, 1366, 1139,
+		1366, 1139, 1251, 682, 1251, 981, 1251, 682, 1479, 1251, 1479, 1251, 1479, 1251, 1479, 1251,
+		1479, 1251, 1479, 1251, 1933, 1593, 1366, 1139, 1366, 1251, 1024, 1251, 1024, 1251, 1024, 629,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 1139, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1479, 1251, 928,
+		592, 1593, 1251, 1479, 1251, 1479, 1251, 1479, 1251, 1479, 1251, 1479, 1251, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1479, 1139, 2048, 1821, 1593, 1251,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 1366, 1139, 1251, 682, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	},
+}
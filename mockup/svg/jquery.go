@@ -0,0 +1,299 @@
+//go:build js
+
+package svg
+
+import (
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/gopherjs/jquery"
+)
+
+// This file is the DOM layer of the package, it is only built for the browser.
+// Everything else renders without a JavaScript runtime, see nodom.go.
+
+var jQuery = jquery.NewJQuery
+
+// JQ creates the element on the page, every SvgElement of the package can
+func JQ(se SvgElement) jquery.JQuery {
+	return se.(interface{ JQ() jquery.JQuery }).JQ()
+}
+
+// hasDOM reports whether the package runs in a browser, without it elements are only rendered to strings
+func hasDOM() bool {
+	return js.Global != nil && js.Global.Get("document") != js.Undefined
+}
+
+// updateAttr sets attributes of the element with the id on the page
+func updateAttr(id string, attr attrs) {
+	if id == "" || !hasDOM() {
+		return
+	}
+	jQuery("#" + id).SetAttr(js.M(attr))
+}
+
+// updateHtml replaces the content of the element with the id on the page
+func updateHtml(id string, html string) {
+	if id == "" || !hasDOM() {
+		return
+	}
+	jQuery("#" + id).SetHtml(html)
+}
+
+func mergeAttr(m1, m2 js.M) js.M {
+	for k, v := range m2 {
+		m1[k] = v
+	}
+	return m1
+}
+
+func initJq(tag string) jquery.JQuery {
+	return jQuery(js.Global.Get("document").Call("createElementNS", "http://www.w3.org/2000/svg", tag))
+}
+
+func (se *Rect) JQ() jquery.JQuery {
+	attr := js.M{
+		"width":  se.Width,
+		"height": se.Height,
+		"x":      se.X,
+		"y":      se.Y,
+	}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Fillable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+	if se.RX != 0 {
+		attr["rx"] = se.RX
+	}
+	if se.RY != 0 {
+		attr["ry"] = se.RY
+	}
+	return initJq("rect").SetAttr(attr)
+}
+
+func (se Strokeable) Attr() js.M {
+	attr := js.M{}
+	if se.Stroke != "" {
+		attr["stroke"] = se.Stroke
+	}
+	if se.StrokeWidth != 0 {
+		attr["stroke-width"] = se.StrokeWidth
+	}
+
+	if se.StrokeDashArray != nil && len(se.StrokeDashArray) > 0 {
+		s := ""
+		for k, v := range se.StrokeDashArray {
+			if k != 0 {
+				s += ","
+			}
+			s += num(v)
+		}
+		attr["stroke-dasharray"] = s
+	}
+
+	if se.StrokeLineCap != BUTT {
+		attr["stroke-linecap"] = strokeLineCapString[se.StrokeLineCap]
+	}
+	return attr
+}
+
+func (editable Editable) Attr() js.M {
+	if editable == 0 {
+		return js.M{}
+	}
+	return js.M{
+		"class": strings.Join(editable.Classes(), " "),
+	}
+}
+
+func (id IDAble) Attr() js.M {
+	if id.ID == "" {
+		return js.M{}
+	}
+
+	return js.M{
+		"id": id.ID,
+	}
+}
+
+func (t Transformable) Attr() js.M {
+	if len(t.Transform) == 0 {
+		return js.M{}
+	}
+	return js.M{
+		"transform": t.Transform.String(),
+	}
+}
+
+func (se Fontable) Attr() js.M {
+	attr := js.M{}
+	if se.FontFamily != "" {
+		attr["font-family"] = se.FontFamily
+	}
+	if se.FontSize != 0 {
+		attr["font-size"] = se.FontSize
+	}
+	if se.Bold {
+		attr["font-weight"] = "bold"
+	}
+	if se.Italic {
+		attr["font-style"] = "italic"
+	}
+	if se.Underline {
+		attr["text-decoration"] = "underline"
+	}
+	if se.TextAnchor != START {
+		attr["text-anchor"] = se.TextAnchor.String()
+	}
+	return attr
+}
+
+func (se fillable) Attr() js.M {
+	attr := js.M{}
+	if se.Fill != "" {
+		attr["fill"] = se.Fill
+	}
+	if se.Opacity != float64(1) {
+		attr["fill-opacity"] = se.Opacity
+	}
+	return attr
+}
+
+func (se *Line) JQ() jquery.JQuery {
+	attr := js.M{
+		"x1": se.X1,
+		"y1": se.Y1,
+		"x2": se.X2,
+		"y2": se.Y2,
+	}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+	return initJq("line").SetAttr(attr)
+}
+
+func (se *Circle) JQ() jquery.JQuery {
+	attr := js.M{
+		"cx": se.X,
+		"cy": se.Y,
+		"r":  se.R,
+	}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Fillable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+	return initJq("circle").SetAttr(attr)
+}
+
+func (se *Ellipse) JQ() jquery.JQuery {
+	attr := js.M{
+		"cx": se.X,
+		"cy": se.Y,
+		"rx": se.RX,
+		"ry": se.RY,
+	}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Fillable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+	return initJq("ellipse").SetAttr(attr)
+}
+
+func (se *Polygon) JQ() jquery.JQuery {
+	attr := js.M{
+		"points": se.Points.String(),
+	}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Fillable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+	return initJq("polygon").SetAttr(attr)
+}
+
+func (se *Polyline) JQ() jquery.JQuery {
+	attr := js.M{
+		"points": se.Points.String(),
+	}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Fillable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+	return initJq("polyline").SetAttr(attr)
+}
+
+func (se *Path) JQ() jquery.JQuery {
+	attr := js.M{
+		"d": se.D.String(),
+	}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Fillable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+
+	return initJq("path").SetAttr(attr)
+}
+
+func (se *Text) JQ() jquery.JQuery {
+	attr := js.M{
+		"x": se.X,
+		"y": se.Y,
+	}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Fillable.Attr())
+	attr = mergeAttr(attr, se.Fontable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+	return initJq("text").SetAttr(attr).SetHtml(se.html())
+}
+
+func (se *Group) JQ() jquery.JQuery {
+	attr := js.M{}
+	attr = mergeAttr(attr, se.IDAble.Attr())
+	attr = mergeAttr(attr, se.Transformable.Attr())
+	attr = mergeAttr(attr, se.Fillable.Attr())
+	attr = mergeAttr(attr, se.Strokeable.Attr())
+	attr = mergeAttr(attr, se.Editable.Attr())
+	s := ""
+	for _, v := range se.Content {
+		s += v.String()
+	}
+	return initJq("g").SetAttr(attr).SetHtml(s)
+}
+
+// CanvasMeasurer measures text with a canvas 2d context, the way the browser renders it
+type CanvasMeasurer struct {
+	ctx *js.Object
+}
+
+func NewCanvasMeasurer() *CanvasMeasurer {
+	canvas := js.Global.Get("document").Call("createElement", "canvas")
+	return &CanvasMeasurer{ctx: canvas.Call("getContext", "2d")}
+}
+
+func (m *CanvasMeasurer) Measure(s string, f Font) TextMetrics {
+	m.ctx.Set("font", f.CSS())
+	tm := m.ctx.Call("measureText", s)
+	metrics := GoFontMeasurer{}.Measure("", f)
+	metrics.Width = tm.Get("width").Float()
+	// older browsers only measure the width, the line keeps the proportions of the Go font then
+	if ascent := tm.Get("fontBoundingBoxAscent"); ascent != js.Undefined {
+		metrics.Ascent = ascent.Float()
+		metrics.Descent = tm.Get("fontBoundingBoxDescent").Float()
+	}
+	return metrics
+}
+
+func defaultTextMeasurer() TextMeasurer {
+	if hasDOM() {
+		return NewCanvasMeasurer()
+	}
+	return GoFontMeasurer{}
+}
@@ -0,0 +1,104 @@
+package svg
+
+import (
+	"strconv"
+	"unicode"
+)
+
+// Font is what a text is set in
+type Font struct {
+	Family string
+	Size   float64
+	Bold   bool
+	Italic bool
+}
+
+// DefaultFont is the font of a text without font attributes
+var DefaultFont = Font{Family: "sans-serif", Size: 16}
+
+// CSS is the font in the shorthand of the css font property
+func (f Font) CSS() string {
+	s := ""
+	if f.Italic {
+		s += "italic "
+	}
+	if f.Bold {
+		s += "bold "
+	}
+	return s + strconv.FormatFloat(f.Size, 'f', -1, 64) + "px " + f.Family
+}
+
+// TextMetrics are the extent of a line of text in user units.
+// Ascent and Descent are the distances from the baseline to the top and bottom of the line.
+type TextMetrics struct {
+	Width   float64
+	Ascent  float64
+	Descent float64
+}
+
+func (m TextMetrics) Height() float64 {
+	return m.Ascent + m.Descent
+}
+
+// TextMeasurer measures a line of text in a font
+type TextMeasurer interface {
+	Measure(s string, f Font) TextMetrics
+}
+
+var textMeasurer TextMeasurer
+
+// SetTextMeasurer replaces the measurer used for text layout, nil restores the default
+func SetTextMeasurer(m TextMeasurer) {
+	textMeasurer = m
+}
+
+// MeasureText measures s with the measurer set by SetTextMeasurer.
+// By default text is measured by the browser, or with the Go font metrics without one.
+func MeasureText(s string, f Font) TextMetrics {
+	if textMeasurer == nil {
+		textMeasurer = defaultTextMeasurer()
+	}
+	return textMeasurer.Measure(s, f)
+}
+
+//go:generate go run gen_metrics.go
+
+// GoFontMeasurer measures text with the metrics of the Go fonts, whatever the family.
+// It matches the font of the headless renderer.
+type GoFontMeasurer struct{}
+
+func (GoFontMeasurer) Measure(s string, f Font) TextMetrics {
+	style := goRegular
+	if f.Bold {
+		style += goBold
+	}
+	if f.Italic {
+		style += goItalic
+	}
+	units := 0
+	for _, r := range s {
+		units += goFontAdvance(r, style)
+	}
+	scale := f.Size / goFontUnitsPerEm
+	return TextMetrics{
+		Width:   float64(units) * scale,
+		Ascent:  goFontAscent * scale,
+		Descent: goFontDescent * scale,
+	}
+}
+
+// goFontAdvance is the advance of a rune, runes without metrics are as wide as an "n",
+// or a whole em for wide scripts
+func goFontAdvance(r rune, style int) int {
+	advances := goFontAdvances[style]
+	if i := int(r) - goFontFirstRune; i >= 0 && i < len(advances) && advances[i] != 0 {
+		return int(advances[i])
+	}
+	if unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hangul, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) {
+		return goFontUnitsPerEm
+	}
+	if !unicode.IsPrint(r) || unicode.Is(unicode.Mn, r) {
+		return 0
+	}
+	return int(advances['n'-goFontFirstRune])
+}
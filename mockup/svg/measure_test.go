@@ -0,0 +1,75 @@
+package svg
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+// em is a font whose size is in font units, the widths it measures are the advances
+var em = Font{Size: goFontUnitsPerEm}
+
+func TestGoFontMeasurer(t *testing.T) {
+	m := GoFontMeasurer{}
+	n := float64(goFontAdvances[goRegular]['n'-goFontFirstRune])
+	if got := m.Measure("n", em).Width; got != n {
+		t.Errorf("n is %v wide, want %v", got, n)
+	}
+	// the width scales with the size, the family doesn't change it
+	if got := m.Measure("nn", Font{Size: goFontUnitsPerEm / 2}).Width; got != n {
+		t.Errorf("nn at half the size is %v wide, want %v", got, n)
+	}
+	if got := m.Measure("n", Font{Family: "serif", Size: goFontUnitsPerEm}).Width; got != n {
+		t.Errorf("n in serif is %v wide, want %v", got, n)
+	}
+	// the styles have metrics of their own
+	styles := map[Font]int{
+		{Size: goFontUnitsPerEm, Bold: true}:               goBold,
+		{Size: goFontUnitsPerEm, Italic: true}:             goItalic,
+		{Size: goFontUnitsPerEm, Bold: true, Italic: true}: goBoldItalic,
+	}
+	for f, style := range styles {
+		if got, want := m.Measure("n", f).Width, float64(goFontAdvances[style]['n'-goFontFirstRune]); got != want {
+			t.Errorf("n in %v is %v wide, want %v", f, got, want)
+		}
+	}
+	if got := m.Measure("", em); got.Width != 0 || got.Ascent != goFontAscent || got.Descent != goFontDescent {
+		t.Errorf("empty string measures %+v", got)
+	}
+
+	cases := []struct {
+		s     string
+		width float64
+	}{
+		{"\u00e9", float64(goFontAdvances[goRegular][0xe9-goFontFirstRune])},
+		// beyond the metrics a rune is an n, an em in wide scripts, nothing when it is not drawn
+		{"Ω", n},
+		{"中", goFontUnitsPerEm},
+		{"e\u0301", float64(goFontAdvances[goRegular]['e'-goFontFirstRune])},
+		{"\t", 0},
+	}
+	for _, c := range cases {
+		if got := m.Measure(c.s, em).Width; got != c.width {
+			t.Errorf("%q is %v wide, want %v", c.s, got, c.width)
+		}
+	}
+}
+
+// runeMeasurer makes every rune as wide as the font size
+type runeMeasurer struct{}
+
+func (runeMeasurer) Measure(s string, f Font) TextMetrics {
+	return TextMetrics{Width: float64(utf8.RuneCountInString(s)) * f.Size, Ascent: f.Size}
+}
+
+func TestSetTextMeasurer(t *testing.T) {
+	f := Font{Size: 10}
+	SetTextMeasurer(runeMeasurer{})
+	if got := MeasureText("abc", f).Width; got != 30 {
+		t.Errorf("measured %v with the measurer set, want 30", got)
+	}
+	// without a browser the Go fonts measure text by default
+	SetTextMeasurer(nil)
+	if got, want := MeasureText("abc", f).Width, (GoFontMeasurer{}).Measure("abc", f).Width; got != want {
+		t.Errorf("measured %v by default, want %v", got, want)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !js
+
+package svg
+
+// Outside the browser there is no page to update, elements are only rendered to strings.
+
+func hasDOM() bool {
+	return false
+}
+
+func updateAttr(id string, attr attrs) {}
+
+func updateHtml(id string, html string) {}
+
+func defaultTextMeasurer() TextMeasurer {
+	return GoFontMeasurer{}
+}
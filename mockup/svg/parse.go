@@ -0,0 +1,370 @@
+package svg
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Parse reads svg markup back into an Svg.
+// The markup may be a whole <svg> document or a sequence of elements.
+// Elements the package cannot represent are skipped with their children.
+func Parse(r io.Reader) (*Svg, error) {
+	d := xml.NewDecoder(r)
+	d.Entity = xml.HTMLEntity
+	svg := &Svg{}
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return svg, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local == "svg" {
+			if err := svg.parse(d, start); err != nil {
+				return nil, err
+			}
+			return svg, nil
+		}
+		ele, err := parseElement(d, start)
+		if err != nil {
+			return nil, err
+		}
+		if ele != nil {
+			svg.Content = append(svg.Content, ele)
+		}
+	}
+}
+
+func ParseString(s string) (*Svg, error) {
+	return Parse(strings.NewReader(s))
+}
+
+func (svg *Svg) parse(d *xml.Decoder, start xml.StartElement) error {
+	a := newAttrReader(start)
+	svg.Width = a.float("width")
+	svg.Height = a.float("height")
+	if vb := strings.FieldsFunc(a.get("viewBox"), isSeparator); len(vb) == 4 {
+		svg.ViewBox = &ViewBox{
+			X:      parseNum(vb[0]),
+			Y:      parseNum(vb[1]),
+			Width:  parseNum(vb[2]),
+			Height: parseNum(vb[3]),
+		}
+	}
+	content, err := parseContent(d)
+	svg.Content = content
+	return err
+}
+
+// elementParsers build an element from its start tag, the element's children are read by parseElement
+var elementParsers = map[string]func(a attrReader) SvgElement{
+	"rect": func(a attrReader) SvgElement {
+		return &Rect{
+			Width:         a.float("width"),
+			Height:        a.float("height"),
+			X:             a.float("x"),
+			Y:             a.float("y"),
+			RX:            a.float("rx"),
+			RY:            a.float("ry"),
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+			Fillable:      a.fillable(),
+		}
+	},
+	"line": func(a attrReader) SvgElement {
+		return &Line{
+			X1:            a.float("x1"),
+			Y1:            a.float("y1"),
+			X2:            a.float("x2"),
+			Y2:            a.float("y2"),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+		}
+	},
+	"circle": func(a attrReader) SvgElement {
+		return &Circle{
+			X:             a.float("cx"),
+			Y:             a.float("cy"),
+			R:             a.float("r"),
+			Fillable:      a.fillable(),
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+		}
+	},
+	"ellipse": func(a attrReader) SvgElement {
+		return &Ellipse{
+			X:             a.float("cx"),
+			Y:             a.float("cy"),
+			RX:            a.float("rx"),
+			RY:            a.float("ry"),
+			Fillable:      a.fillable(),
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+		}
+	},
+	"polygon": func(a attrReader) SvgElement {
+		return &Polygon{
+			Points:        a.points("points"),
+			Fillable:      a.fillable(),
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+		}
+	},
+	"polyline": func(a attrReader) SvgElement {
+		return &Polyline{
+			Points:        a.points("points"),
+			Fillable:      a.fillable(),
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+		}
+	},
+	"text": func(a attrReader) SvgElement {
+		return &Text{
+			X:             a.float("x"),
+			Y:             a.float("y"),
+			Fillable:      a.fillable(),
+			Fontable:      a.fontable(),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+		}
+	},
+	"path": func(a attrReader) SvgElement {
+		// like browsers, keep the commands before an error in the path data
+		d, _ := ParsePathData(a.get("d"))
+		return &Path{
+			D:             d,
+			Fillable:      a.fillable(),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+		}
+	},
+	"g": func(a attrReader) SvgElement {
+		return &Group{
+			IDAble:        a.idable(),
+			Transformable: a.transformable(),
+			Fillable:      a.fillable(),
+			Strokeable:    a.strokeable(),
+			Editable:      a.editable(),
+		}
+	},
+}
+
+// parseElement reads the element that start opens up to its end tag.
+// It returns nil for elements the package does not know.
+func parseElement(d *xml.Decoder, start xml.StartElement) (SvgElement, error) {
+	parser, ok := elementParsers[start.Name.Local]
+	if !ok {
+		return nil, d.Skip()
+	}
+	ele := parser(newAttrReader(start))
+	switch se := ele.(type) {
+	case *Group:
+		content, err := parseContent(d)
+		se.Content = content
+		return se, err
+	case *Text:
+		return se, se.parse(d)
+	}
+	return ele, d.Skip()
+}
+
+// parseContent reads child elements up to the end tag of their parent
+func parseContent(d *xml.Decoder) ([]SvgElement, error) {
+	content := []SvgElement{}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return content, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			ele, err := parseElement(d, t)
+			if err != nil {
+				return content, err
+			}
+			if ele != nil {
+				content = append(content, ele)
+			}
+		case xml.EndElement:
+			return content, nil
+		}
+	}
+}
+
+// parse reads the content of a text element. A <tspan> child starts a line,
+// placed at its x, y, or dy below the previous line, the character data of
+// other nested elements joins the current line.
+func (se *Text) parse(d *xml.Decoder) error {
+	line := &se.Content
+	x, y := se.X, se.Y
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			*line += string(t)
+		case xml.StartElement:
+			depth++
+			if depth == 1 && t.Name.Local == "tspan" {
+				a := newAttrReader(t)
+				if _, ok := a["x"]; ok {
+					x = a.float("x")
+				}
+				if _, ok := a["y"]; ok {
+					y = a.float("y")
+				}
+				y += a.float("dy")
+				se.Spans = append(se.Spans, TSpan{X: x, Y: y})
+				line = &se.Spans[len(se.Spans)-1].Content
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				if len(se.Spans) > 0 && strings.TrimSpace(se.Content) == "" {
+					// the indentation between lines
+					se.Content = ""
+				}
+				return nil
+			}
+			depth--
+			if depth == 0 {
+				// text after a line belongs to the text itself
+				line = &se.Content
+			}
+		}
+	}
+}
+
+// attrReader looks up the attributes of an element, presentation attributes may also come from its style
+type attrReader map[string]string
+
+func newAttrReader(start xml.StartElement) attrReader {
+	a := attrReader{}
+	for _, attr := range start.Attr {
+		a[attr.Name.Local] = attr.Value
+	}
+	for _, decl := range strings.Split(a["style"], ";") {
+		if kv := strings.SplitN(decl, ":", 2); len(kv) == 2 {
+			a[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+	return a
+}
+
+func (a attrReader) get(name string) string {
+	return strings.TrimSpace(a[name])
+}
+
+func (a attrReader) float(name string) float64 {
+	return parseNum(a.get(name))
+}
+
+// points reads a list of coordinate pairs, an odd coordinate at the end is dropped
+func (a attrReader) points(name string) Points {
+	v := strings.FieldsFunc(a.get(name), isSeparator)
+	p := Points{}
+	for i := 0; i+1 < len(v); i += 2 {
+		p = append(p, NewPoint(parseNum(v[i]), parseNum(v[i+1])))
+	}
+	return p
+}
+
+func (a attrReader) idable() IDAble {
+	return IDAble{ID: a.get("id")}
+}
+
+// transformable ignores a transform it can't read, leaving the element untransformed
+func (a attrReader) transformable() Transformable {
+	t, err := ParseTransform(a.get("transform"))
+	if err != nil {
+		return Transformable{}
+	}
+	return Transformable{Transform: t}
+}
+
+func (a attrReader) editable() Editable {
+	return ParseEditable(strings.Fields(a.get("class"))...)
+}
+
+func (a attrReader) fillable() fillable {
+	f := NewFillable(a.get("fill"), 1)
+	if _, ok := a["fill-opacity"]; ok {
+		f.Opacity = a.float("fill-opacity")
+	}
+	return f
+}
+
+func (a attrReader) fontable() Fontable {
+	f := Fontable{
+		FontFamily: a.get("font-family"),
+		FontSize:   a.float("font-size"),
+		Italic:     a.get("font-style") == "italic" || a.get("font-style") == "oblique",
+		Underline:  strings.Contains(a.get("text-decoration"), "underline"),
+	}
+	switch w := a.get("font-weight"); w {
+	case "bold", "bolder":
+		f.Bold = true
+	default:
+		f.Bold = parseNum(w) >= 600
+	}
+	for k, v := range textAnchorString {
+		if a.get("text-anchor") == v {
+			f.TextAnchor = TextAnchor(k)
+		}
+	}
+	return f
+}
+
+func (a attrReader) strokeable() Strokeable {
+	s := Strokeable{
+		Stroke:      a.get("stroke"),
+		StrokeWidth: a.float("stroke-width"),
+	}
+	for _, v := range strings.FieldsFunc(a.get("stroke-dasharray"), isSeparator) {
+		s.StrokeDashArray = append(s.StrokeDashArray, parseNum(v))
+	}
+	for k, v := range strokeLineCapString {
+		if a.get("stroke-linecap") == v {
+			s.StrokeLineCap = StrokeLineCap(k)
+		}
+	}
+	return s
+}
+
+func isSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// parseNum reads a number, ignoring a px unit, invalid numbers are 0
+func parseNum(s string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "px"), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
@@ -0,0 +1,143 @@
+package svg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := ParseString(`<svg width="100" height="50px" viewBox="0,0 200 100">
+	<defs><rect width="9" height="9"/></defs>
+	<rect id="r" x="1" y="2" width="3px" height="4" style="fill: blue; stroke-width: 2" class="draggable"/>
+	<g id="g" transform="translate(5,5) scale(2)"><line x1="0" y1="0" x2="5" y2="5" stroke="red"/><text x="1" y="2" font-size="12" font-weight="700" text-anchor="middle">
+		<tspan x="1" y="14">a &amp; b</tspan><tspan dy="12">c<b>d</b></tspan>
+	</text></g>
+</svg>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Width != 100 || doc.Height != 50 || *doc.ViewBox != (ViewBox{0, 0, 200, 100}) {
+		t.Errorf("svg of %v x %v in %v", doc.Width, doc.Height, doc.ViewBox)
+	}
+	// the rect in defs isn't drawn, it is skipped
+	if len(doc.Content) != 2 {
+		t.Fatalf("%d elements, want 2", len(doc.Content))
+	}
+
+	r, ok := doc.Content[0].(*Rect)
+	if !ok {
+		t.Fatalf("first element is a %T", doc.Content[0])
+	}
+	if r.ID != "r" || r.X != 1 || r.Y != 2 || r.Width != 3 || r.Height != 4 || r.Editable != DRAGGABLE {
+		t.Errorf("rect %+v", r)
+	}
+	if r.Fillable != NewFillable("blue", 1) || r.StrokeWidth != 2 {
+		t.Errorf("rect styled with %+v and %+v", r.Fillable, r.Strokeable)
+	}
+
+	g, ok := doc.Content[1].(*Group)
+	if !ok || len(g.Content) != 2 {
+		t.Fatalf("second element %s", doc.Content[1])
+	}
+	if got := g.Transform.String(); got != "translate(5 5) scale(2)" {
+		t.Errorf("group transformed by %q", got)
+	}
+	if l, ok := g.Content[0].(*Line); !ok || l.X2 != 5 || l.Y2 != 5 || l.Stroke != "red" {
+		t.Errorf("line %s", g.Content[0])
+	}
+	// a tspan is a line, the text of other nested elements is part of it
+	text, ok := g.Content[1].(*Text)
+	if !ok || text.Content != "" || !reflect.DeepEqual(text.Spans, []TSpan{NewTSpan("a & b", 1, 14), NewTSpan("cd", 1, 26)}) {
+		t.Fatalf("text %s", g.Content[1])
+	}
+	// a numeric weight from 600 is bold
+	if text.Fontable != (Fontable{FontSize: 12, Bold: true, TextAnchor: MIDDLE}) {
+		t.Errorf("text in %+v", text.Fontable)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, markup := range []string{`<svg><rect></svg>`, `<svg>`, `<svg><text>a</svg>`} {
+		if _, err := ParseString(markup); err == nil {
+			t.Errorf("%s parsed", markup)
+		}
+	}
+}
+
+// TestParseRoundTrip reads back what String writes
+func TestParseRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		doc  Svg
+	}{
+		{"empty", Svg{Width: 10, Height: 20}},
+		{"view box", Svg{Width: 10, Height: 20, ViewBox: &ViewBox{X: -5, Y: -5, Width: 20, Height: 30}}},
+		{"shapes", Svg{Width: 100, Height: 100, Content: []SvgElement{
+			&Rect{X: 1, Y: 2, Width: 3, Height: 4, RX: 1, IDAble: IDAble{ID: "r"}, Fillable: NewFillable("#fff", 0.5), Transformable: Transformable{Transform: Transform{Rotate(30, 2, 4)}},
+				Strokeable: Strokeable{Stroke: "black", StrokeWidth: 2, StrokeDashArray: []float64{4, 2}}, Editable: EDITABLE | DRAGGABLE},
+			&Line{X1: 1, Y1: 2, X2: 3, Y2: 4, Strokeable: Strokeable{Stroke: "blue", StrokeLineCap: ROUND}},
+			&Path{D: PathItems{NewPathItem(MOVETO, NewPoint(0, 0)), NewArcItem(Arc{RX: 5, RY: 5, Sweep: true}, NewPoint(10, 0)), NewPathItem(CLOSEPATH)}, Fillable: NewFillable("", 1)},
+		}}},
+		{"curves and polygons", Svg{Width: 100, Height: 100, Content: []SvgElement{
+			&Circle{X: 5, Y: 5, R: 2.5, IDAble: IDAble{ID: "c"}, Fillable: NewFillable("red", 1), Editable: DRAGGABLE},
+			&Ellipse{X: 10, Y: 5, RX: 4, RY: 2, Fillable: NewFillable("", 0.5), Strokeable: Strokeable{Stroke: "black", StrokeWidth: 1}},
+			&Polygon{Points: Points{NewPoint(0, 0), NewPoint(10, 0), NewPoint(5, 8.5)}, Fillable: NewFillable("blue", 1)},
+			&Polyline{Points: Points{NewPoint(0, 0), NewPoint(-1.5, 2)}, Strokeable: Strokeable{Stroke: "green", StrokeLineCap: ROUND}},
+		}}},
+		{"nested groups", Svg{Width: 100, Height: 100, Content: []SvgElement{
+			&Group{IDAble: IDAble{ID: "outer"}, Fillable: NewFillable("", 1), Transformable: Transformable{Transform: Transform{Translate(5, 5), Scale(2, 2)}}, Content: []SvgElement{
+				&Group{IDAble: IDAble{ID: "inner"}, Fillable: NewFillable("", 1), Content: []SvgElement{
+					&Rect{Width: 1, Height: 1, Fillable: NewFillable("", 1)},
+				}},
+			}},
+		}}},
+		{"text", Svg{Width: 100, Height: 100, Content: []SvgElement{
+			&Text{X: 1, Y: 2, Content: `<a> & "b"`, Fillable: NewFillable("black", 1),
+				Fontable: Fontable{FontFamily: "serif", FontSize: 12, Italic: true, Underline: true, TextAnchor: END}},
+			&Text{X: 1, Y: 2, Spans: []TSpan{NewTSpan("one", 1, 14), NewTSpan("two", 1, 28)}, Fillable: NewFillable("", 1)},
+		}}},
+	}
+	for _, c := range cases {
+		want := c.doc.String()
+		doc, err := ParseString(want)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if got := doc.String(); got != want {
+			t.Errorf("%s: read back\n%s\nwant\n%s", c.name, got, want)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	doc, err := ParseString(`<path id="p" d="M0 0 L1 1 L" fill="red" transform="spin(3)"/>`)
+	if err != nil || len(doc.Content) != 1 {
+		t.Fatal(doc, err)
+	}
+	// like in a browser the path is drawn up to the error in its data, without the transform it can't read
+	p, ok := doc.Content[0].(*Path)
+	if !ok || p.ID != "p" || p.Fillable.Fill != "red" || p.D.String() != "M 0 0 L 1 1" || len(p.Transform) != 0 {
+		t.Errorf("path %s", doc.Content[0])
+	}
+}
+
+func TestParseShapes(t *testing.T) {
+	doc, err := ParseString(`<svg><circle cx="1" cy="2" r="3"/><ellipse cx="1" cy="2" rx="3" ry="4"/><polygon points="0,0 10,0 5,5"/><polyline points="0 0, 1 1 2,0"/></svg>`)
+	if err != nil || len(doc.Content) != 4 {
+		t.Fatal(doc, err)
+	}
+	if c, ok := doc.Content[0].(*Circle); !ok || c.X != 1 || c.Y != 2 || c.R != 3 {
+		t.Errorf("circle %s", doc.Content[0])
+	}
+	if e, ok := doc.Content[1].(*Ellipse); !ok || e.X != 1 || e.Y != 2 || e.RX != 3 || e.RY != 4 {
+		t.Errorf("ellipse %s", doc.Content[1])
+	}
+	if p, ok := doc.Content[2].(*Polygon); !ok || p.Points.String() != "0,0 10,0 5,5" {
+		t.Errorf("polygon %s", doc.Content[2])
+	}
+	// points are separated by commas, spaces or both
+	if p, ok := doc.Content[3].(*Polyline); !ok || p.Points.String() != "0,0 1,1 2,0" {
+		t.Errorf("polyline %s", doc.Content[3])
+	}
+}
@@ -0,0 +1,543 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type pathAction int
+
+const (
+	MOVETO pathAction = iota
+	LINETO
+	HORIZONTAL_LINETO
+	VERTICAL_LINETO
+	CURVETO
+	SMOOTH_CURVETO
+	QUADRATIC_BEZIER_CURVE
+	SMOOTH_QUADRATIC_BEZIER_CURVETO
+	ELLIPTICAL_ARC
+	CLOSEPATH
+)
+
+// MoveTo is the former name of MOVETO
+const MoveTo = MOVETO
+
+var pathActionString = "MLHVCSQTAZ"
+
+// pathActionPoints is the number of points each action takes,
+// HORIZONTAL_LINETO and VERTICAL_LINETO only use one coordinate of theirs
+var pathActionPoints = []int{1, 1, 1, 1, 3, 2, 2, 1, 1, 0}
+
+func (pa pathAction) String() string {
+	return pathActionString[pa : pa+1]
+}
+
+// Arc holds the radii and flags of an ELLIPTICAL_ARC
+type Arc struct {
+	RX       float64
+	RY       float64
+	Rotation float64
+	LargeArc bool
+	Sweep    bool
+}
+
+// PathItem is one command of the path data.
+// Points are the control points followed by the end point, relative to the current point when Relative is set.
+// HORIZONTAL_LINETO only uses the x of its point and VERTICAL_LINETO the y.
+type PathItem struct {
+	Action   pathAction
+	Relative bool
+	Points   []Point
+	Arc      Arc
+}
+
+func NewPathItem(action pathAction, points ...Point) PathItem {
+	return PathItem{
+		Action: action,
+		Points: points,
+	}
+}
+
+func NewArcItem(arc Arc, to Point) PathItem {
+	return PathItem{
+		Action: ELLIPTICAL_ARC,
+		Points: []Point{to},
+		Arc:    arc,
+	}
+}
+
+// End returns the end point of the command, CLOSEPATH has none
+func (item PathItem) End() (Point, bool) {
+	if len(item.Points) == 0 {
+		return Point{}, false
+	}
+	return item.Points[len(item.Points)-1], true
+}
+
+func (item PathItem) String() string {
+	s := item.Action.String()
+	if item.Relative {
+		s = strings.ToLower(s)
+	}
+	switch item.Action {
+	case CLOSEPATH:
+		return s
+	case HORIZONTAL_LINETO:
+		return s + " " + num(item.Points[0].x)
+	case VERTICAL_LINETO:
+		return s + " " + num(item.Points[0].y)
+	case ELLIPTICAL_ARC:
+		s += " " + num(item.Arc.RX) + " " + num(item.Arc.RY) + " " + num(item.Arc.Rotation) + " " + flag(item.Arc.LargeArc) + " " + flag(item.Arc.Sweep)
+	}
+	for _, p := range item.Points {
+		s += " " + num(p.x) + " " + num(p.y)
+	}
+	return s
+}
+
+func flag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+type PathItems []PathItem
+
+// SvgElement
+type Path struct {
+	D        PathItems `svg:"d"`
+	Fillable fillable
+	Strokeable
+	Editable
+	IDAble
+	Transformable
+}
+
+func (ps PathItems) String() string {
+	s := make([]string, len(ps))
+	for i := range ps {
+		s[i] = ps[i].String()
+	}
+	return strings.Join(s, " ")
+}
+
+// Absolute returns the path with every command in absolute form
+func (ps PathItems) Absolute() PathItems {
+	abs := make(PathItems, len(ps))
+	cur, start := Point{}, Point{}
+	for k, item := range ps {
+		item.Points = append([]Point{}, item.Points...)
+		if item.Relative {
+			for i := range item.Points {
+				item.Points[i].x += cur.x
+				item.Points[i].y += cur.y
+			}
+			item.Relative = false
+		}
+		switch item.Action {
+		case HORIZONTAL_LINETO:
+			item.Points[0].y = cur.y
+		case VERTICAL_LINETO:
+			item.Points[0].x = cur.x
+		case CLOSEPATH:
+			cur = start
+		}
+		if end, ok := item.End(); ok {
+			cur = end
+		}
+		if item.Action == MOVETO {
+			start = cur
+		}
+		abs[k] = item
+	}
+	return abs
+}
+
+// segment is a piece of an absolute path running from the current point,
+// smooth curves have their control point resolved and horizontal and vertical lines are LINETO
+type segment struct {
+	action pathAction
+	from   Point
+	points []Point
+	arc    Arc
+}
+
+// segments walks the path, the last point of each segment is where it ends.
+// CLOSEPATH ends on the first point of its subpath.
+func (ps PathItems) segments() []segment {
+	segs := []segment{}
+	cur, start, ctrl := Point{}, Point{}, Point{}
+	prev := CLOSEPATH
+	for _, item := range ps.Absolute() {
+		pts := item.Points
+		// reflection of the previous control point, for the smooth curves
+		refl := cur
+		switch {
+		case (item.Action == SMOOTH_CURVETO) && (prev == CURVETO || prev == SMOOTH_CURVETO),
+			(item.Action == SMOOTH_QUADRATIC_BEZIER_CURVETO) && (prev == QUADRATIC_BEZIER_CURVE || prev == SMOOTH_QUADRATIC_BEZIER_CURVETO):
+			refl = Point{2*cur.x - ctrl.x, 2*cur.y - ctrl.y}
+		}
+
+		seg := segment{action: item.Action, from: cur, points: pts, arc: item.Arc}
+		switch item.Action {
+		case MOVETO:
+			start = pts[0]
+		case HORIZONTAL_LINETO, VERTICAL_LINETO:
+			seg.action = LINETO
+		case CURVETO:
+			ctrl = pts[1]
+		case SMOOTH_CURVETO:
+			seg.action = CURVETO
+			seg.points = []Point{refl, pts[0], pts[1]}
+			ctrl = pts[0]
+		case QUADRATIC_BEZIER_CURVE:
+			ctrl = pts[0]
+		case SMOOTH_QUADRATIC_BEZIER_CURVETO:
+			seg.action = QUADRATIC_BEZIER_CURVE
+			seg.points = []Point{refl, pts[0]}
+			ctrl = refl
+		case CLOSEPATH:
+			seg.points = []Point{start}
+		}
+		segs = append(segs, seg)
+		cur = seg.points[len(seg.points)-1]
+		prev = item.Action
+	}
+	return segs
+}
+
+// Flatten approximates the path with polylines, one per subpath.
+// A subpath ended by CLOSEPATH finishes on its first point.
+func (ps PathItems) Flatten() [][]Point {
+	polys := [][]Point{}
+	poly := []Point{}
+	flush := func(start Point) {
+		if len(poly) > 1 {
+			polys = append(polys, poly)
+		}
+		poly = []Point{start}
+	}
+
+	for _, seg := range ps.segments() {
+		pts := seg.points
+		switch seg.action {
+		case MOVETO:
+			flush(pts[0])
+		case LINETO:
+			poly = append(poly, pts[0])
+		case CURVETO:
+			poly = append(poly, cubicPoints(seg.from, pts[0], pts[1], pts[2])...)
+		case QUADRATIC_BEZIER_CURVE:
+			poly = append(poly, quadPoints(seg.from, pts[0], pts[1])...)
+		case ELLIPTICAL_ARC:
+			poly = append(poly, arcPoints(seg.from, pts[0], seg.arc)...)
+		case CLOSEPATH:
+			poly = append(poly, pts[0])
+			flush(pts[0])
+		}
+	}
+	flush(Point{})
+	return polys
+}
+
+var curveSegments = 16
+
+func cubicPoints(p0, p1, p2, p3 Point) []Point {
+	pts := make([]Point, curveSegments)
+	for i := range pts {
+		t := float64(i+1) / float64(curveSegments)
+		pts[i] = cubicAt(p0, p1, p2, p3, t)
+	}
+	return pts
+}
+
+func cubicAt(p0, p1, p2, p3 Point, t float64) Point {
+	mt := 1 - t
+	a, b, c, d := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+	return Point{
+		x: a*p0.x + b*p1.x + c*p2.x + d*p3.x,
+		y: a*p0.y + b*p1.y + c*p2.y + d*p3.y,
+	}
+}
+
+func quadPoints(p0, p1, p2 Point) []Point {
+	pts := make([]Point, curveSegments)
+	for i := range pts {
+		t := float64(i+1) / float64(curveSegments)
+		pts[i] = quadAt(p0, p1, p2, t)
+	}
+	return pts
+}
+
+func quadAt(p0, p1, p2 Point, t float64) Point {
+	mt := 1 - t
+	a, b, c := mt*mt, 2*mt*t, t*t
+	return Point{
+		x: a*p0.x + b*p1.x + c*p2.x,
+		y: a*p0.y + b*p1.y + c*p2.y,
+	}
+}
+
+// arcCenter converts an arc from p1 to p2 to its center parameterization,
+// following the SVG implementation notes. It reports false when the arc is a straight line.
+func arcCenter(p1, p2 Point, arc Arc) (c Point, rx, ry, phi, theta, delta float64, ok bool) {
+	rx, ry = math.Abs(arc.RX), math.Abs(arc.RY)
+	if rx == 0 || ry == 0 || p1 == p2 {
+		return c, rx, ry, 0, 0, 0, false
+	}
+	phi = arc.Rotation * math.Pi / 180
+	sin, cos := math.Sin(phi), math.Cos(phi)
+	dx, dy := (p1.x-p2.x)/2, (p1.y-p2.y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+
+	if l := x1*x1/(rx*rx) + y1*y1/(ry*ry); l > 1 {
+		rx *= math.Sqrt(l)
+		ry *= math.Sqrt(l)
+	}
+	n := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	d := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(0, n/d))
+	if arc.LargeArc == arc.Sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+	c = Point{
+		x: cos*cx1 - sin*cy1 + (p1.x+p2.x)/2,
+		y: sin*cx1 + cos*cy1 + (p1.y+p2.y)/2,
+	}
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta = angle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta = angle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if !arc.Sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if arc.Sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+	return c, rx, ry, phi, theta, delta, true
+}
+
+func ellipseAt(c Point, rx, ry, phi, t float64) Point {
+	sin, cos := math.Sin(phi), math.Cos(phi)
+	return Point{
+		x: c.x + rx*math.Cos(t)*cos - ry*math.Sin(t)*sin,
+		y: c.y + rx*math.Cos(t)*sin + ry*math.Sin(t)*cos,
+	}
+}
+
+func arcPoints(p1, p2 Point, arc Arc) []Point {
+	c, rx, ry, phi, theta, delta, ok := arcCenter(p1, p2, arc)
+	if !ok {
+		return []Point{p2}
+	}
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 16)))
+	pts := make([]Point, n)
+	for i := range pts {
+		pts[i] = ellipseAt(c, rx, ry, phi, theta+delta*float64(i+1)/float64(n))
+	}
+	pts[n-1] = p2
+	return pts
+}
+
+// ParsePathData reads the d attribute of a path.
+// On a syntax error it returns the commands read so far along with the error, as browsers render them.
+func ParsePathData(d string) (PathItems, error) {
+	sc := &pathScanner{s: d}
+	items := PathItems{}
+	var last byte
+	for {
+		sc.skipSeparators()
+		if sc.eof() {
+			return items, nil
+		}
+		c := sc.s[sc.i]
+		if i := strings.IndexByte(pathActionString+strings.ToLower(pathActionString), c); i >= 0 {
+			sc.i++
+		} else if last != 0 && !isPathClose(last) {
+			// implicit repetition of the previous command, a moveto goes on with linetos
+			c = last
+			if c == 'M' {
+				c = 'L'
+			} else if c == 'm' {
+				c = 'l'
+			}
+		} else {
+			return items, sc.errorf("expected a command")
+		}
+
+		item, err := sc.item(c)
+		if err != nil {
+			return items, err
+		}
+		if len(items) == 0 && item.Action != MOVETO {
+			return items, sc.errorf("path data must start with a moveto")
+		}
+		items = append(items, item)
+		last = c
+	}
+}
+
+func isPathClose(c byte) bool {
+	return c == 'Z' || c == 'z'
+}
+
+type pathScanner struct {
+	s string
+	i int
+}
+
+func (sc *pathScanner) eof() bool {
+	return sc.i >= len(sc.s)
+}
+
+func (sc *pathScanner) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("svg: invalid path data at offset %d: %s", sc.i, fmt.Sprintf(format, args...))
+}
+
+func (sc *pathScanner) skipSeparators() {
+	for !sc.eof() && strings.IndexByte(" \t\r\n,", sc.s[sc.i]) >= 0 {
+		sc.i++
+	}
+}
+
+func (sc *pathScanner) item(c byte) (PathItem, error) {
+	action := pathAction(strings.IndexByte(pathActionString, strings.ToUpper(string(c))[0]))
+	item := PathItem{
+		Action:   action,
+		Relative: c >= 'a',
+	}
+	if action == ELLIPTICAL_ARC {
+		vals := [3]float64{}
+		for k := range vals {
+			v, err := sc.number()
+			if err != nil {
+				return item, err
+			}
+			vals[k] = v
+		}
+		large, err := sc.flag()
+		if err != nil {
+			return item, err
+		}
+		sweep, err := sc.flag()
+		if err != nil {
+			return item, err
+		}
+		item.Arc = Arc{RX: vals[0], RY: vals[1], Rotation: vals[2], LargeArc: large, Sweep: sweep}
+	}
+
+	for k := 0; k < pathActionPoints[action]; k++ {
+		p := Point{}
+		var err error
+		switch action {
+		case HORIZONTAL_LINETO:
+			p.x, err = sc.number()
+		case VERTICAL_LINETO:
+			p.y, err = sc.number()
+		default:
+			if p.x, err = sc.number(); err == nil {
+				p.y, err = sc.number()
+			}
+		}
+		if err != nil {
+			return item, err
+		}
+		item.Points = append(item.Points, p)
+	}
+	return item, nil
+}
+
+func (sc *pathScanner) flag() (bool, error) {
+	sc.skipSeparators()
+	if sc.eof() || (sc.s[sc.i] != '0' && sc.s[sc.i] != '1') {
+		return false, sc.errorf("expected a flag")
+	}
+	sc.i++
+	return sc.s[sc.i-1] == '1', nil
+}
+
+func (sc *pathScanner) number() (float64, error) {
+	sc.skipSeparators()
+	start := sc.i
+	digits := func() int {
+		n := 0
+		for !sc.eof() && sc.s[sc.i] >= '0' && sc.s[sc.i] <= '9' {
+			sc.i++
+			n++
+		}
+		return n
+	}
+	if !sc.eof() && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+		sc.i++
+	}
+	n := digits()
+	if !sc.eof() && sc.s[sc.i] == '.' {
+		sc.i++
+		n += digits()
+	}
+	if n == 0 {
+		sc.i = start
+		return 0, sc.errorf("expected a number")
+	}
+	if !sc.eof() && (sc.s[sc.i] == 'e' || sc.s[sc.i] == 'E') {
+		mark := sc.i
+		sc.i++
+		if !sc.eof() && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+			sc.i++
+		}
+		if digits() == 0 {
+			sc.i = mark
+		}
+	}
+	return strconv.ParseFloat(sc.s[start:sc.i], 64)
+}
+
+func (se *Path) String() string {
+	s := `<path d="` + se.D.String() + `"`
+	s += se.IDAble.String()
+	s += se.Transformable.String()
+	s += se.Fillable.String()
+	s += se.Strokeable.String()
+	s += se.Editable.String()
+	s += ` >` + unSupportMsg + `</path>`
+	return s
+}
+
+// MoveTo translates the path so it starts at x, y.
+// Relative commands move along with the absolute ones before them.
+func (se *Path) MoveTo(x, y float64) {
+	if len(se.D) == 0 || len(se.D[0].Points) == 0 {
+		return
+	}
+	dx := x - se.D[0].Points[0].x
+	dy := y - se.D[0].Points[0].y
+	for k := range se.D {
+		item := &se.D[k]
+		if item.Relative && k != 0 {
+			continue
+		}
+		item.Points = append([]Point{}, item.Points...)
+		for i := range item.Points {
+			item.Points[i].x += dx
+			item.Points[i].y += dy
+		}
+	}
+	updateAttr(se.ID, attrs{"d": se.D.String()})
+}
+
+func (se *Path) ResizeTo(w, h float64) {
+	//do nothing
+}
+
+func (se *Path) SetD(d PathItems) {
+	se.D = d
+	updateAttr(se.ID, attrs{"d": se.D.String()})
+}
@@ -0,0 +1,86 @@
+package svg
+
+import (
+	"testing"
+)
+
+func TestParsePathData(t *testing.T) {
+	cases := []struct {
+		d    string
+		want string
+		err  bool
+	}{
+		{d: "M10 20 L30 40", want: "M 10 20 L 30 40"},
+		{d: "m1,2 3,4", want: "m 1 2 l 3 4"},
+		{d: "M0 0H10V5h-2v1Z", want: "M 0 0 H 10 V 5 h -2 v 1 Z"},
+		{d: "M0 0C1 2 3 4 5 6S7 8 9 10", want: "M 0 0 C 1 2 3 4 5 6 S 7 8 9 10"},
+		{d: "M0 0Q1 1 2 0T4 0 6 0", want: "M 0 0 Q 1 1 2 0 T 4 0 T 6 0"},
+		{d: "M0 0A5 5 0 1 0 10 10", want: "M 0 0 A 5 5 0 1 0 10 10"},
+		{d: "M0 0a5 5 30 1110 10", want: "M 0 0 a 5 5 30 1 1 10 10"},
+		{d: "M.5-.5e1 1.5.5", want: "M 0.5 -5 L 1.5 0.5"},
+		{d: "M0 0zm1 1", want: "M 0 0 z m 1 1"},
+		{d: "", want: ""},
+		{d: "L1 2", want: "", err: true},
+		{d: "M1", want: "", err: true},
+		{d: "M0 0 L1", want: "M 0 0", err: true},
+		{d: "M0 0 X", want: "M 0 0", err: true},
+		{d: "M0 0 A1 1 0 2 0 3 3", want: "M 0 0", err: true},
+	}
+	for _, c := range cases {
+		items, err := ParsePathData(c.d)
+		if (err != nil) != c.err {
+			t.Errorf("%q: error %v, want error %v", c.d, err, c.err)
+		}
+		if got := items.String(); got != c.want {
+			t.Errorf("%q: got %q, want %q", c.d, got, c.want)
+		}
+		if c.err {
+			continue
+		}
+		// the written path data reads back the same
+		again, err := ParsePathData(c.want)
+		if err != nil || again.String() != c.want {
+			t.Errorf("%q: read back %q, %v", c.want, again.String(), err)
+		}
+	}
+}
+
+func TestPathAbsolute(t *testing.T) {
+	items, err := ParsePathData("m1 1 2 0zl0 5h3v-1H0c1 1 2 2 3 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// after z the current point is back on the start of the subpath
+	want := "M 1 1 L 3 1 Z L 1 6 H 4 V 5 H 0 C 1 6 2 7 3 8"
+	if got := items.Absolute().String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPathFlatten(t *testing.T) {
+	items, err := ParsePathData("M0 0 H10 V10 Z M20 0 C20 10 30 10 30 0 M40 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	polys := items.Flatten()
+	// the lone move draws nothing
+	if len(polys) != 2 {
+		t.Fatalf("%d polylines, want 2", len(polys))
+	}
+	square := Points(polys[0]).String()
+	if want := "0,0 10,0 10,10 0,0"; square != want {
+		t.Errorf("closed subpath %q, want %q", square, want)
+	}
+	curve := polys[1]
+	if first, last := curve[0], curve[len(curve)-1]; first != NewPoint(20, 0) || last != NewPoint(30, 0) {
+		t.Errorf("curve from %v to %v", first, last)
+	}
+	if len(curve) != curveSegments+1 {
+		t.Errorf("curve of %d points, want %d", len(curve), curveSegments+1)
+	}
+	for _, p := range curve {
+		if p.y < 0 || p.y > 7.5 {
+			t.Errorf("curve through %v, out of its control polygon", p)
+		}
+	}
+}
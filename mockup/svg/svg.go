@@ -1,41 +1,48 @@
 package svg
 
 import (
-	"github.com/gopherjs/gopherjs/js"
-	"github.com/gopherjs/jquery"
+	"math"
+	"strconv"
+	"strings"
 )
 
-var jQuery = jquery.NewJQuery
-
 type Svg struct {
 	Width   float64
 	Height  float64
+	ViewBox *ViewBox
 	Content []SvgElement
 }
 
-func jsString(a interface{}) string {
-	if a != nil {
-		s := js.Global.Call("String", a).String()
-		if s != "undefined" && s != "null" {
-			return s
-		}
-	}
-	return ""
+// ViewBox is the area of the user space shown by an Svg
+type ViewBox struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
 }
 
-func mergeAttr(m1, m2 js.M) js.M {
-	for k, v := range m2 {
-		m1[k] = v
+func (vb *ViewBox) String() string {
+	if vb == nil {
+		return ""
 	}
-	return m1
+	return ` viewBox="` + num(vb.X) + ` ` + num(vb.Y) + ` ` + num(vb.Width) + ` ` + num(vb.Height) + `"`
 }
 
-func initJq(tag string) jquery.JQuery {
-	return jQuery(js.Global.Get("document").Call("createElementNS", "http://www.w3.org/2000/svg", tag))
+// num formats a number in the shortest decimal form that reads back as the same value.
+// Unlike JavaScript's String() it never switches to an exponent for very large or small values.
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+var escaper = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&#34;", `'`, "&#39;")
+
+// escape makes s safe to use as text content or as an attribute value
+func escape(s string) string {
+	return escaper.Replace(s)
 }
 
 func (svg Svg) String() string {
-	result := `<svg width="` + jsString(svg.Width) + `" height="` + jsString(svg.Height) + `">`
+	result := `<svg xmlns="http://www.w3.org/2000/svg" width="` + num(svg.Width) + `" height="` + num(svg.Height) + `"` + svg.ViewBox.String() + `>`
 	for _, v := range svg.Content {
 		result += v.String()
 	}
@@ -43,11 +50,14 @@ func (svg Svg) String() string {
 	return result
 }
 
+// attrs are attribute values of an element
+type attrs map[string]interface{}
+
 type SvgElement interface {
 	String() string
-	JQ() jquery.JQuery
 	MoveTo(x, y float64)
 	ResizeTo(w, h float64)
+	BBox() BBox
 }
 
 // Rect implement SvgElement interface
@@ -59,6 +69,7 @@ type Rect struct {
 	RX     float64 `svg:"rx"`
 	RY     float64 `svg:"ry"`
 	IDAble
+	Transformable
 	Strokeable
 	Editable
 	Fillable fillable
@@ -67,45 +78,26 @@ type Rect struct {
 var unSupportMsg = "Sorry, your browser does not support inline SVG."
 
 func (se *Rect) String() string {
-	s := `<rect width="` + jsString(se.Width) + `" height="` + jsString(se.Height) + `" x="` + jsString(se.X) + `" y="` + jsString(se.Y) + `"`
-	s += se.Idable.String()
+	s := `<rect width="` + num(se.Width) + `" height="` + num(se.Height) + `" x="` + num(se.X) + `" y="` + num(se.Y) + `"`
+	s += se.IDAble.String()
+	s += se.Transformable.String()
 	s += se.Fillable.String()
 	s += se.Strokeable.String()
 	s += se.Editable.String()
 	if se.RX != 0 {
-		s += ` rx="` + jsString(se.RX) + `"`
+		s += ` rx="` + num(se.RX) + `"`
 	}
 	if se.RY != 0 {
-		s += ` ry="` + jsString(se.RY) + `"`
+		s += ` ry="` + num(se.RY) + `"`
 	}
 	s += ` >` + unSupportMsg + `</rect>`
 	return s
 }
 
-func (se *Rect) JQ() jquery.JQuery {
-	attr := js.M{
-		"width":  se.Width,
-		"height": se.Height,
-		"x":      se.X,
-		"y":      se.Y,
-	}
-	attr = mergeAttr(attr, se.IDAble.Attr())
-	attr = mergeAttr(attr, se.Fillable.Attr())
-	attr = mergeAttr(attr, se.Strokeable.Attr())
-	attr = mergeAttr(attr, se.Editable.Attr())
-	if se.RX != 0 {
-		attr["rx"] = se.RX
-	}
-	if se.RY != 0 {
-		attr["ry"] = se.RY
-	}
-	return initJq("rect").SetAttr(attr)
-}
-
 func (se *Rect) MoveTo(x, y float64) {
 	se.X = x
 	se.Y = y
-	jQuery("#" + se.ID).SetAttr(js.M{
+	updateAttr(se.ID, attrs{
 		"x": se.X,
 		"y": se.Y,
 	})
@@ -114,7 +106,7 @@ func (se *Rect) MoveTo(x, y float64) {
 func (se *Rect) ResizeTo(w, h float64) {
 	se.Width = w
 	se.Height = h
-	jQuery("#" + se.ID).SetAttr(js.M{
+	updateAttr(se.ID, attrs{
 		"width":  se.Width,
 		"height": se.Height,
 	})
@@ -140,10 +132,10 @@ type Strokeable struct {
 func (se Strokeable) String() string {
 	s := ""
 	if se.Stroke != "" {
-		s += ` stroke="` + jsString(se.Stroke) + `"`
+		s += ` stroke="` + escape(se.Stroke) + `"`
 	}
 	if se.StrokeWidth != 0 {
-		s += ` stroke-width="` + jsString(se.StrokeWidth) + `"`
+		s += ` stroke-width="` + num(se.StrokeWidth) + `"`
 	}
 
 	if se.StrokeDashArray != nil && len(se.StrokeDashArray) > 0 {
@@ -152,7 +144,7 @@ func (se Strokeable) String() string {
 			if k != 0 {
 				s += ","
 			}
-			s += jsString(v)
+			s += num(v)
 		}
 		s += `"`
 	}
@@ -164,33 +156,6 @@ func (se Strokeable) String() string {
 	return s
 }
 
-func (se Strokeable) Attr() js.M {
-	attr := js.M{}
-	if se.Stroke != "" {
-		attr["stroke"] = se.Stroke
-	}
-	if se.StrokeWidth != 0 {
-		attr["stroke-width"] = se.StrokeWidth
-	}
-
-	if se.StrokeDashArray != nil && len(se.StrokeDashArray) > 0 {
-		s := `"`
-		for k, v := range se.StrokeDashArray {
-			if k != 0 {
-				s += ","
-			}
-			s += jsString(v)
-		}
-		s += `"`
-		attr["stroke-dasharray"] = s
-	}
-
-	if se.StrokeLineCap != BUTT {
-		attr["stroke-linecap"] = strokeLineCapString[se.StrokeLineCap]
-	}
-	return attr
-}
-
 const (
 	INEDITABLE Editable = 1 << iota
 	CLONABLE
@@ -202,6 +167,8 @@ const (
 	NESW_RESIZABLE
 	NWSE_RESIZABLE
 	LINE_VERTEX
+	ROTATABLE
+	SELECTION_AREA
 )
 
 var editable_class = []string{
@@ -215,9 +182,11 @@ var editable_class = []string{
 	"nesw-resizable",
 	"nwse-resizable",
 	"line-vertex",
+	"rotatable",
+	"selection-area",
 }
 
-//choose only 1
+// choose only 1
 func (editable Editable) JQSelector() string {
 	i := 0
 	for editable>>1 != 0 || editable != 0 {
@@ -237,25 +206,34 @@ func NewEditablePtr(e Editable) *Editable {
 }
 
 func (editable Editable) String() string {
-	if c := editable.Attr()["class"].(string); c != "" {
+	if c := strings.Join(editable.Classes(), " "); c != "" {
 		return ` class="` + c + `"`
 	}
 	return ""
 }
 
-func (editable Editable) Attr() js.M {
-	i := 0
-	class := ""
-	for editable>>1 != 0 || editable != 0 {
-		if editable-editable>>1<<1 == 1 {
-			class += " " + editable_class[i]
+// Classes returns the class name of every flag set in editable
+func (editable Editable) Classes() []string {
+	classes := []string{}
+	for i := range editable_class {
+		if editable&(1<<uint(i)) != 0 {
+			classes = append(classes, editable_class[i])
 		}
-		i++
-		editable = editable >> 1
 	}
-	return js.M{
-		"class": class[1:],
+	return classes
+}
+
+// ParseEditable maps class names back to their Editable flags, unknown names are ignored
+func ParseEditable(classes ...string) Editable {
+	editable := Editable(0)
+	for _, c := range classes {
+		for i, v := range editable_class {
+			if c == v {
+				editable |= 1 << uint(i)
+			}
+		}
 	}
+	return editable
 }
 
 type IDAble struct {
@@ -266,17 +244,7 @@ func (id IDAble) String() string {
 	if id.ID == "" {
 		return ""
 	}
-	return ` id="` + jsString(id.ID) + `"`
-}
-
-func (id IDAble) Attr() js.M {
-	if id.ID == "" {
-		return js.M{}
-	}
-
-	return js.M{
-		"id": id.ID,
-	}
+	return ` id="` + escape(id.ID) + `"`
 }
 
 type fillable struct {
@@ -295,39 +263,32 @@ func (se fillable) String() string {
 	s := ""
 
 	if se.Fill != "" {
-		s += ` fill="` + jsString(se.Fill) + `"`
+		s += ` fill="` + escape(se.Fill) + `"`
 	}
 
 	if se.Opacity != float64(1) {
-		s += ` fill-opacity="` + jsString(se.Opacity) + `"`
+		s += ` fill-opacity="` + num(se.Opacity) + `"`
 	}
 
 	return s
 }
 
-func (se fillable) Attr() js.M {
-	attr := js.M{}
-	if se.Fill != "" {
-		attr["fill"] = se.Fill
-	}
-	if se.Opacity != float64(1) {
-		attr["fill-opacity"] = se.Opacity
-	}
-	return attr
-}
-
-//SvgElement
+// SvgElement
 type Circle struct {
 	X        float64 `svg:"cx"`
 	Y        float64 `svg:"cy"`
 	R        float64 `svg:"r"`
 	Fillable fillable
+	IDAble
+	Transformable
 	Strokeable
 	Editable
 }
 
-func (se Circle) String() string {
-	s := `<circle r="` + jsString(se.R) + `" cx="` + jsString(se.X) + `" cy="` + jsString(se.Y) + `"`
+func (se *Circle) String() string {
+	s := `<circle r="` + num(se.R) + `" cx="` + num(se.X) + `" cy="` + num(se.Y) + `"`
+	s += se.IDAble.String()
+	s += se.Transformable.String()
 	s += se.Fillable.String()
 	s += se.Strokeable.String()
 	s += se.Editable.String()
@@ -335,19 +296,43 @@ func (se Circle) String() string {
 	return s
 }
 
-//SvgElement
+// MoveTo moves the top left corner of the bounding box to x, y
+func (se *Circle) MoveTo(x, y float64) {
+	se.X = x + se.R
+	se.Y = y + se.R
+	updateAttr(se.ID, attrs{
+		"cx": se.X,
+		"cy": se.Y,
+	})
+}
+
+// ResizeTo fits the circle into a w x h box, keeping the top left corner of the bounding box
+func (se *Circle) ResizeTo(w, h float64) {
+	x, y := se.X-se.R, se.Y-se.R
+	se.R = math.Max(math.Min(w, h), 0) / 2
+	se.MoveTo(x, y)
+	updateAttr(se.ID, attrs{
+		"r": se.R,
+	})
+}
+
+// SvgElement
 type Ellipse struct {
 	X        float64 `svg:"cx"`
 	Y        float64 `svg:"cy"`
 	RX       float64 `svg:"rx"`
 	RY       float64 `svg:"ry"`
 	Fillable fillable
+	IDAble
+	Transformable
 	Strokeable
 	Editable
 }
 
-func (se Ellipse) String() string {
-	s := `<ellipse rx="` + jsString(se.RX) + `" ry="` + jsString(se.RY) + `" cx="` + jsString(se.X) + `" cy="` + jsString(se.Y) + `"`
+func (se *Ellipse) String() string {
+	s := `<ellipse rx="` + num(se.RX) + `" ry="` + num(se.RY) + `" cx="` + num(se.X) + `" cy="` + num(se.Y) + `"`
+	s += se.IDAble.String()
+	s += se.Transformable.String()
 	s += se.Fillable.String()
 	s += se.Strokeable.String()
 	s += se.Editable.String()
@@ -355,7 +340,29 @@ func (se Ellipse) String() string {
 	return s
 }
 
-//SvgElement
+// MoveTo moves the top left corner of the bounding box to x, y
+func (se *Ellipse) MoveTo(x, y float64) {
+	se.X = x + se.RX
+	se.Y = y + se.RY
+	updateAttr(se.ID, attrs{
+		"cx": se.X,
+		"cy": se.Y,
+	})
+}
+
+// ResizeTo stretches the ellipse to a w x h bounding box, keeping its top left corner
+func (se *Ellipse) ResizeTo(w, h float64) {
+	x, y := se.X-se.RX, se.Y-se.RY
+	se.RX = math.Max(w, 0) / 2
+	se.RY = math.Max(h, 0) / 2
+	se.MoveTo(x, y)
+	updateAttr(se.ID, attrs{
+		"rx": se.RX,
+		"ry": se.RY,
+	})
+}
+
+// SvgElement
 type Line struct {
 	X1 float64 `svg:"x1"`
 	Y1 float64 `svg:"y1"`
@@ -364,30 +371,19 @@ type Line struct {
 	Strokeable
 	Editable
 	IDAble
+	Transformable
 }
 
 func (se *Line) String() string {
-	s := `<line x1="` + jsString(se.X1) + `" y1="` + jsString(se.Y1) + `" x2="` + jsString(se.X2) + `" y2="` + jsString(se.Y2) + `"`
-	s += se.Idable.String()
+	s := `<line x1="` + num(se.X1) + `" y1="` + num(se.Y1) + `" x2="` + num(se.X2) + `" y2="` + num(se.Y2) + `"`
+	s += se.IDAble.String()
+	s += se.Transformable.String()
 	s += se.Strokeable.String()
 	s += se.Editable.String()
 	s += ` >` + unSupportMsg + `</line>`
 	return s
 }
 
-func (se *Line) JQ() jquery.JQuery {
-	attr := js.M{
-		"x1": se.X1,
-		"y1": se.Y1,
-		"x2": se.X2,
-		"y2": se.Y2,
-	}
-	attr = mergeAttr(attr, se.Idable.Attr())
-	attr = mergeAttr(attr, se.Strokeable.Attr())
-	attr = mergeAttr(attr, se.Editable.Attr())
-	return initJq("line").SetAttr(attr)
-}
-
 func (se *Line) MoveTo(x, y float64) {
 	dx := x - se.X1
 	dy := y - se.Y1
@@ -395,7 +391,7 @@ func (se *Line) MoveTo(x, y float64) {
 	se.Y1 = y
 	se.X2 += dx
 	se.Y2 += dy
-	jQuery("#" + se.ID).SetAttr(js.M{
+	updateAttr(se.ID, attrs{
 		"x1": se.X1,
 		"y1": se.Y1,
 		"x2": se.X2,
@@ -407,14 +403,14 @@ func (se *Line) PointTo(x, y float64, pt int) {
 	if pt == 1 {
 		se.X1 = x
 		se.Y1 = y
-		jQuery("#" + se.ID).SetAttr(js.M{
+		updateAttr(se.ID, attrs{
 			"x1": se.X1,
 			"y1": se.Y1,
 		})
 	} else {
 		se.X2 = x
 		se.Y2 = y
-		jQuery("#" + se.ID).SetAttr(js.M{
+		updateAttr(se.ID, attrs{
 			"x2": se.X2,
 			"y2": se.Y2,
 		})
@@ -437,12 +433,20 @@ func NewPoint(x, y float64) Point {
 	}
 }
 
+func (p Point) X() float64 {
+	return p.x
+}
+
+func (p Point) Y() float64 {
+	return p.y
+}
+
 type Points []Point
 
 func (p Points) String() string {
 	s := ""
 	for i := 0; i < len(p); i++ {
-		s += jsString(p[i].x) + "," + jsString(p[i].y)
+		s += num(p[i].x) + "," + num(p[i].y)
 		if i != len(p)-1 {
 			s += " "
 		}
@@ -450,16 +454,43 @@ func (p Points) String() string {
 	return s
 }
 
-//SvgElement
+// moveTo translates the points so their bounding box starts at x, y
+func (p Points) moveTo(x, y float64) {
+	b := p.BBox()
+	for i := range p {
+		p[i].x += x - b.X
+		p[i].y += y - b.Y
+	}
+}
+
+// resizeTo scales the points relative to the top left corner of their bounding box,
+// a flat side of the box stays flat
+func (p Points) resizeTo(w, h float64) {
+	b := p.BBox()
+	for i := range p {
+		if b.Width != 0 {
+			p[i].x = b.X + (p[i].x-b.X)*w/b.Width
+		}
+		if b.Height != 0 {
+			p[i].y = b.Y + (p[i].y-b.Y)*h/b.Height
+		}
+	}
+}
+
+// SvgElement
 type Polygon struct {
 	Points   Points `svg:"points"`
 	Fillable fillable
+	IDAble
+	Transformable
 	Strokeable
 	Editable
 }
 
-func (se Polygon) String() string {
+func (se *Polygon) String() string {
 	s := `<polygon points="` + se.Points.String() + `"`
+	s += se.IDAble.String()
+	s += se.Transformable.String()
 	s += se.Fillable.String()
 	s += se.Strokeable.String()
 	s += se.Editable.String()
@@ -467,16 +498,34 @@ func (se Polygon) String() string {
 	return s
 }
 
-//SvgElement
+func (se *Polygon) MoveTo(x, y float64) {
+	se.Points.moveTo(x, y)
+	updateAttr(se.ID, attrs{
+		"points": se.Points.String(),
+	})
+}
+
+func (se *Polygon) ResizeTo(w, h float64) {
+	se.Points.resizeTo(w, h)
+	updateAttr(se.ID, attrs{
+		"points": se.Points.String(),
+	})
+}
+
+// SvgElement
 type Polyline struct {
 	Points   Points `svg:"points"`
 	Fillable fillable
+	IDAble
+	Transformable
 	Strokeable
 	Editable
 }
 
-func (se Polyline) String() string {
+func (se *Polyline) String() string {
 	s := `<polyline points="` + se.Points.String() + `"`
+	s += se.IDAble.String()
+	s += se.Transformable.String()
 	s += se.Fillable.String()
 	s += se.Strokeable.String()
 	s += se.Editable.String()
@@ -484,137 +533,160 @@ func (se Polyline) String() string {
 	return s
 }
 
-type pathAction int
-
-const (
-	MoveTo pathAction = iota
-	LINETO
-	HORIZONTAL_LINETO
-	VERTICAL_LINETO
-	CURVETO
-	SMOOTH_CURVETO
-	QUADRATIC_BEZIER_CURVE
-	SMOOTH_QUADRATIC_BEZIER_CURVETO
-	ELLIPTICAL_ARC
-	CLOSEPATH
-)
-
-var pathActionString = "MLHVCSQTAZ"
-
-func (pa pathAction) String() string {
-	return pathActionString[pa : pa+1]
+func (se *Polyline) MoveTo(x, y float64) {
+	se.Points.moveTo(x, y)
+	updateAttr(se.ID, attrs{
+		"points": se.Points.String(),
+	})
 }
 
-type PathItem struct {
-	Action pathAction
-	Point  Point
+func (se *Polyline) ResizeTo(w, h float64) {
+	se.Points.resizeTo(w, h)
+	updateAttr(se.ID, attrs{
+		"points": se.Points.String(),
+	})
 }
 
-type PathItems []PathItem
-
-//SvgElement
-type Path struct {
-	D        PathItems `svg:"d"`
+type Text struct {
+	Content string `svg:"content"`
+	// Spans are lines following the content, placed on their own
+	Spans    []TSpan `svg:"content"`
+	X        float64 `svg:"x"`
+	Y        float64 `svg:"y"`
 	Fillable fillable
+	Fontable
 	Strokeable
 	Editable
 	IDAble
+	Transformable
 }
 
-func (ps PathItems) String() string {
-	s := ""
-	for i := 0; i < len(ps); i++ {
-		s += ps[i].Action.String()
-		if ps[i].Action != CLOSEPATH {
-			s += " " + jsString(ps[i].Point.x) + " " + jsString(ps[i].Point.y)
-		}
-		if i != len(ps)-1 {
-			s += " "
-		}
-	}
-	return s
-}
-
-func (se *Path) String() string {
-	s := `<path d="` + se.D.String() + `"`
-	s += se.Idable.String()
-	s += se.Fillable.String()
-	s += se.Strokeable.String()
-	s += se.Editable.String()
-	s += ` >` + unSupportMsg + `</path>`
-	return s
-}
+type TextAnchor int
 
-func (se *Path) Jq() jquery.JQuery {
-	attr := js.M{
-		"d": se.D.String(),
-	}
-	attr = mergeAttr(attr, se.Fillable.Attr())
-	attr = mergeAttr(attr, se.Strokeable.Attr())
-	attr = mergeAttr(attr, se.Editable.Attr())
+const (
+	START TextAnchor = iota
+	MIDDLE
+	END
+)
 
-	return initJq("path").SetAttr(attr)
-}
+var textAnchorString = []string{"start", "middle", "end"}
 
-func (se *Path) MoveTo(x, y float64) {
-	dx := x - se.D[0].Point.x
-	dy := y - se.D[0].Point.y
-	for k := range se.D {
-		se.D[k].Point.x += dx
-		se.D[k].Point.y += dy
-	}
-	jQuery("#"+se.ID).SetAttr("d", se.D.String())
+func (anchor TextAnchor) String() string {
+	return textAnchorString[anchor]
 }
 
-func (se *Path) ResizeTo(w, h float64) {
-	//do nothing
+// Fontable are the font attributes of a text, zero values are left to the default
+type Fontable struct {
+	FontFamily string     `svg:"font-family"`
+	FontSize   float64    `svg:"font-size"`
+	Bold       bool       `svg:"font-weight"`
+	Italic     bool       `svg:"font-style"`
+	Underline  bool       `svg:"text-decoration"`
+	TextAnchor TextAnchor `svg:"text-anchor"`
 }
 
-func (se *Path) SetD(d PathItems) {
-	se.D = d
-	jQuery("#"+se.ID).SetAttr("d", se.D.String())
+func (se Fontable) String() string {
+	s := ""
+	if se.FontFamily != "" {
+		s += ` font-family="` + escape(se.FontFamily) + `"`
+	}
+	if se.FontSize != 0 {
+		s += ` font-size="` + num(se.FontSize) + `"`
+	}
+	if se.Bold {
+		s += ` font-weight="bold"`
+	}
+	if se.Italic {
+		s += ` font-style="italic"`
+	}
+	if se.Underline {
+		s += ` text-decoration="underline"`
+	}
+	if se.TextAnchor != START {
+		s += ` text-anchor="` + se.TextAnchor.String() + `"`
+	}
+	return s
 }
 
-type Text struct {
-	Content  string  `svg:"content"`
-	X        float64 `svg:"x"`
-	Y        float64 `svg:"y"`
-	Fillable fillable
-	Strokeable
-	Editable
-	IDAble
+// Font is the font the text is set in, DefaultFont fills in the attributes left out
+func (se Fontable) Font() Font {
+	f := DefaultFont
+	if se.FontFamily != "" {
+		f.Family = se.FontFamily
+	}
+	if se.FontSize != 0 {
+		f.Size = se.FontSize
+	}
+	f.Bold = se.Bold
+	f.Italic = se.Italic
+	return f
 }
 
 func (se *Text) String() string {
-	s := `<text x="` + jsString(se.X) + `" y="` + jsString(se.Y) + `"`
-	s += se.Idable.String()
+	s := `<text x="` + num(se.X) + `" y="` + num(se.Y) + `"`
+	s += se.IDAble.String()
+	s += se.Transformable.String()
 	s += se.Fillable.String()
+	s += se.Fontable.String()
 	s += se.Strokeable.String()
 	s += se.Editable.String()
-	s += ` >` + se.Content + `</text>`
+	s += ` >` + se.html() + `</text>`
 	return s
 }
 
-func (se *Text) JQ() jquery.JQuery {
-	attr := js.M{
-		"x": se.X,
-		"y": se.Y,
+// html is the markup inside the text element
+func (se *Text) html() string {
+	s := escape(se.Content)
+	for _, span := range se.Spans {
+		s += span.String()
 	}
-	attr = mergeAttr(attr, se.Idable.Attr())
-	attr = mergeAttr(attr, se.Fillable.Attr())
-	attr = mergeAttr(attr, se.Strokeable.Attr())
-	attr = mergeAttr(attr, se.Editable.Attr())
-	return initJq("text").SetAttr(attr).SetHtml(se.Content)
+	return s
 }
 
+// MoveTo moves the start of the text to x, y, its spans move along
 func (se *Text) MoveTo(x, y float64) {
+	dx, dy := x-se.X, y-se.Y
 	se.X = x
 	se.Y = y
-	jQuery("#" + se.ID).SetAttr(js.M{
+	updateAttr(se.ID, attrs{
 		"x": se.X,
 		"y": se.Y,
 	})
+	if len(se.Spans) == 0 {
+		return
+	}
+	spans := make([]TSpan, len(se.Spans))
+	for k, span := range se.Spans {
+		span.X += dx
+		span.Y += dy
+		spans[k] = span
+	}
+	se.SetSpans(spans)
+}
+
+// SetSpans replaces the lines of the text
+func (se *Text) SetSpans(spans []TSpan) {
+	se.Spans = spans
+	updateHtml(se.ID, se.html())
+}
+
+// TSpan is a line of a text starting at X, Y
+type TSpan struct {
+	Content string
+	X       float64
+	Y       float64
+}
 
+func NewTSpan(content string, x, y float64) TSpan {
+	return TSpan{
+		Content: content,
+		X:       x,
+		Y:       y,
+	}
+}
+
+func (span TSpan) String() string {
+	return `<tspan x="` + num(span.X) + `" y="` + num(span.Y) + `">` + escape(span.Content) + `</tspan>`
 }
 
 func (se *Text) ResizeTo(w, h float64) {
@@ -624,6 +696,7 @@ func (se *Text) ResizeTo(w, h float64) {
 type Group struct {
 	Content []SvgElement `svg:"content"`
 	IDAble
+	Transformable
 	Fillable fillable
 	Strokeable
 	Editable
@@ -631,7 +704,8 @@ type Group struct {
 
 func (se *Group) String() string {
 	s := `<g `
-	s += se.Idable.String()
+	s += se.IDAble.String()
+	s += se.Transformable.String()
 	s += se.Fillable.String()
 	s += se.Strokeable.String()
 	s += se.Editable.String()
@@ -643,21 +717,10 @@ func (se *Group) String() string {
 	return s
 }
 
-func (se *Group) JQ() jquery.JQuery {
-	attr := js.M{}
-	attr = mergeAttr(attr, se.Idable.Attr())
-	attr = mergeAttr(attr, se.Fillable.Attr())
-	attr = mergeAttr(attr, se.Strokeable.Attr())
-	attr = mergeAttr(attr, se.Editable.Attr())
-	s := ""
-	for _, v := range se.Content {
-		s += v.String()
-	}
-	return initJq("g").SetAttr(attr).SetHtml(s)
-}
-
+// MoveTo places the origin of the group's content at x, y.
+// Only the group's transform changes, its children keep their coordinates.
 func (se *Group) MoveTo(x, y float64) {
-	//do nothing
+	se.SetTransform(se.Transform.TranslateTo(x, y))
 }
 
 func (se *Group) ResizeTo(w, h float64) {
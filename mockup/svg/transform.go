@@ -0,0 +1,308 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Matrix is the affine transform
+//
+//	| A C E |
+//	| B D F |
+//	| 0 0 1 |
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+var Identity = Matrix{A: 1, D: 1}
+
+// Mul returns the transform applying n, then m
+func (m Matrix) Mul(n Matrix) Matrix {
+	return Matrix{
+		A: m.A*n.A + m.C*n.B,
+		B: m.B*n.A + m.D*n.B,
+		C: m.A*n.C + m.C*n.D,
+		D: m.B*n.C + m.D*n.D,
+		E: m.A*n.E + m.C*n.F + m.E,
+		F: m.B*n.E + m.D*n.F + m.F,
+	}
+}
+
+func (m Matrix) Apply(p Point) Point {
+	return Point{
+		x: m.A*p.x + m.C*p.y + m.E,
+		y: m.B*p.x + m.D*p.y + m.F,
+	}
+}
+
+// Invert reports false when the matrix collapses the plane and can't be inverted
+func (m Matrix) Invert() (Matrix, bool) {
+	det := m.A*m.D - m.B*m.C
+	if det == 0 {
+		return Matrix{}, false
+	}
+	return Matrix{
+		A: m.D / det,
+		B: -m.B / det,
+		C: -m.C / det,
+		D: m.A / det,
+		E: (m.C*m.F - m.D*m.E) / det,
+		F: (m.B*m.E - m.A*m.F) / det,
+	}, true
+}
+
+// BBox is the bounding box of the transformed corners of b
+func (m Matrix) BBox(b BBox) BBox {
+	if m == Identity {
+		return b
+	}
+	return Points{
+		m.Apply(Point{b.X, b.Y}),
+		m.Apply(Point{b.Right(), b.Y}),
+		m.Apply(Point{b.Right(), b.Bottom()}),
+		m.Apply(Point{b.X, b.Bottom()}),
+	}.BBox()
+}
+
+type TransformKind int
+
+const (
+	MATRIX TransformKind = iota
+	TRANSLATE
+	SCALE
+	ROTATE
+	SKEWX
+	SKEWY
+)
+
+var transformKindString = []string{"matrix", "translate", "scale", "rotate", "skewX", "skewY"}
+
+func (k TransformKind) String() string {
+	return transformKindString[k]
+}
+
+// TransformFunc is one function of a transform list, Args are the numbers it is written with
+type TransformFunc struct {
+	Kind TransformKind
+	Args []float64
+}
+
+func Translate(tx, ty float64) TransformFunc {
+	return TransformFunc{Kind: TRANSLATE, Args: []float64{tx, ty}}
+}
+
+func Scale(sx, sy float64) TransformFunc {
+	return TransformFunc{Kind: SCALE, Args: []float64{sx, sy}}
+}
+
+// Rotate turns by angle degrees around cx, cy
+func Rotate(angle, cx, cy float64) TransformFunc {
+	if cx == 0 && cy == 0 {
+		return TransformFunc{Kind: ROTATE, Args: []float64{angle}}
+	}
+	return TransformFunc{Kind: ROTATE, Args: []float64{angle, cx, cy}}
+}
+
+func SkewX(angle float64) TransformFunc {
+	return TransformFunc{Kind: SKEWX, Args: []float64{angle}}
+}
+
+func SkewY(angle float64) TransformFunc {
+	return TransformFunc{Kind: SKEWY, Args: []float64{angle}}
+}
+
+func (m Matrix) Func() TransformFunc {
+	return TransformFunc{Kind: MATRIX, Args: []float64{m.A, m.B, m.C, m.D, m.E, m.F}}
+}
+
+// arg returns the i-th argument, or def when the function is written without it
+func (f TransformFunc) arg(i int, def float64) float64 {
+	if i < len(f.Args) {
+		return f.Args[i]
+	}
+	return def
+}
+
+func (f TransformFunc) Matrix() Matrix {
+	switch f.Kind {
+	case MATRIX:
+		return Matrix{f.arg(0, 1), f.arg(1, 0), f.arg(2, 0), f.arg(3, 1), f.arg(4, 0), f.arg(5, 0)}
+	case TRANSLATE:
+		return Matrix{A: 1, D: 1, E: f.arg(0, 0), F: f.arg(1, 0)}
+	case SCALE:
+		sx := f.arg(0, 1)
+		return Matrix{A: sx, D: f.arg(1, sx)}
+	case ROTATE:
+		a := f.arg(0, 0) * math.Pi / 180
+		cx, cy := f.arg(1, 0), f.arg(2, 0)
+		sin, cos := math.Sin(a), math.Cos(a)
+		r := Matrix{A: cos, B: sin, C: -sin, D: cos}
+		return Matrix{A: 1, D: 1, E: cx, F: cy}.Mul(r).Mul(Matrix{A: 1, D: 1, E: -cx, F: -cy})
+	case SKEWX:
+		return Matrix{A: 1, C: math.Tan(f.arg(0, 0) * math.Pi / 180), D: 1}
+	case SKEWY:
+		return Matrix{A: 1, B: math.Tan(f.arg(0, 0) * math.Pi / 180), D: 1}
+	}
+	return Identity
+}
+
+func (f TransformFunc) String() string {
+	s := make([]string, len(f.Args))
+	for i, v := range f.Args {
+		s[i] = num(v)
+	}
+	return f.Kind.String() + "(" + strings.Join(s, " ") + ")"
+}
+
+// Transform is the transform list of an element, applied from the last function to the first
+type Transform []TransformFunc
+
+// Matrix composes the functions of the list
+func (t Transform) Matrix() Matrix {
+	m := Identity
+	for _, f := range t {
+		m = m.Mul(f.Matrix())
+	}
+	return m
+}
+
+func (t Transform) String() string {
+	s := make([]string, len(t))
+	for i, f := range t {
+		s[i] = f.String()
+	}
+	return strings.Join(s, " ")
+}
+
+// TranslateTo returns the list starting with a translation to x, y,
+// a leading translate is replaced, otherwise one is added in front
+func (t Transform) TranslateTo(x, y float64) Transform {
+	if len(t) > 0 && t[0].Kind == TRANSLATE {
+		return append(Transform{Translate(x, y)}, t[1:]...)
+	}
+	return append(Transform{Translate(x, y)}, t...)
+}
+
+// argCounts are the numbers of arguments each function may be written with
+var argCounts = map[TransformKind][]int{
+	MATRIX:    {6},
+	TRANSLATE: {1, 2},
+	SCALE:     {1, 2},
+	ROTATE:    {1, 3},
+	SKEWX:     {1},
+	SKEWY:     {1},
+}
+
+// ParseTransform reads the transform attribute of an element
+func ParseTransform(s string) (Transform, error) {
+	t := Transform{}
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		open := strings.IndexByte(rest, '(')
+		end := strings.IndexByte(rest, ')')
+		if open < 0 || end < open {
+			return t, fmt.Errorf("svg: invalid transform %q", s)
+		}
+		name := strings.TrimSpace(rest[:open])
+		kind := -1
+		for k, v := range transformKindString {
+			if name == v {
+				kind = k
+			}
+		}
+		if kind < 0 {
+			return t, fmt.Errorf("svg: unknown transform function %q", name)
+		}
+		f := TransformFunc{Kind: TransformKind(kind)}
+		for _, v := range strings.FieldsFunc(rest[open+1:end], isSeparator) {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return t, fmt.Errorf("svg: invalid number %q in transform", v)
+			}
+			f.Args = append(f.Args, n)
+		}
+		valid := false
+		for _, n := range argCounts[f.Kind] {
+			valid = valid || n == len(f.Args)
+		}
+		if !valid {
+			return t, fmt.Errorf("svg: wrong number of arguments to %s", name)
+		}
+		t = append(t, f)
+		rest = strings.TrimLeft(rest[end+1:], " \t\r\n,")
+	}
+	return t, nil
+}
+
+// Transformable is the transform attribute of an element
+type Transformable struct {
+	Transform Transform `svg:"transform"`
+}
+
+func (t Transformable) String() string {
+	if len(t.Transform) == 0 {
+		return ""
+	}
+	return ` transform="` + t.Transform.String() + `"`
+}
+
+// Matrix maps the element's coordinates to its parent's
+func (t Transformable) Matrix() Matrix {
+	return t.Transform.Matrix()
+}
+
+// bbox maps a box in the element's coordinates to its parent's
+func (t Transformable) bbox(b BBox) BBox {
+	if len(t.Transform) == 0 {
+		return b
+	}
+	return t.Transform.Matrix().BBox(b)
+}
+
+// SetTransform replaces the transform of the element
+func (se *Rect) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
+
+func (se *Circle) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
+
+func (se *Ellipse) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
+
+func (se *Line) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
+
+func (se *Polygon) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
+
+func (se *Polyline) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
+
+func (se *Path) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
+
+func (se *Text) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
+
+func (se *Group) SetTransform(t Transform) {
+	se.Transform = t
+	updateAttr(se.ID, attrs{"transform": t.String()})
+}
@@ -0,0 +1,101 @@
+package svg
+
+import (
+	"testing"
+)
+
+func TestParseTransform(t *testing.T) {
+	cases := []struct {
+		s    string
+		want string
+		err  bool
+	}{
+		{s: "", want: ""},
+		{s: "translate(10)", want: "translate(10)"},
+		{s: "translate(1,2) rotate(30 5 5)", want: "translate(1 2) rotate(30 5 5)"},
+		{s: "scale(2)skewX(10)", want: "scale(2) skewX(10)"},
+		{s: " rotate( 45 ) , skewY(-5)", want: "rotate(45) skewY(-5)"},
+		{s: "matrix(1 0 0 1 3 4)", want: "matrix(1 0 0 1 3 4)"},
+		{s: "rotate(1 2)", err: true},
+		{s: "matrix(1 0 0 1)", err: true},
+		{s: "spin(3)", err: true},
+		{s: "translate(a)", err: true},
+		{s: "translate(1", err: true},
+	}
+	for _, c := range cases {
+		tr, err := ParseTransform(c.s)
+		if (err != nil) != c.err {
+			t.Errorf("%q: error %v, want error %v", c.s, err, c.err)
+		}
+		if c.err {
+			continue
+		}
+		if got := tr.String(); got != c.want {
+			t.Errorf("%q: got %q, want %q", c.s, got, c.want)
+		}
+		// the written transform reads back the same
+		again, err := ParseTransform(c.want)
+		if err != nil || again.String() != c.want {
+			t.Errorf("%q: read back %q, %v", c.want, again.String(), err)
+		}
+	}
+}
+
+func near(a, b float64) bool {
+	d := a - b
+	return d < 1e-9 && d > -1e-9
+}
+
+func TestTransformMatrix(t *testing.T) {
+	// the functions apply right to left, the last one first
+	for s, want := range map[string]Point{
+		"translate(10 20) scale(2)": NewPoint(12, 22),
+		"scale(2) translate(10 20)": NewPoint(22, 42),
+		"rotate(90)":                NewPoint(-1, 1),
+		"rotate(180 1 1)":           NewPoint(1, 1),
+		"skewX(45)":                 NewPoint(2, 1),
+		"matrix(1 0 0 1 3 4)":       NewPoint(4, 5),
+	} {
+		tr, err := ParseTransform(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := tr.Matrix()
+		p := m.Apply(NewPoint(1, 1))
+		if !near(p.X(), want.X()) || !near(p.Y(), want.Y()) {
+			t.Errorf("%q: 1, 1 maps to %v, want %v", s, p, want)
+		}
+		inv, ok := m.Invert()
+		if back := inv.Apply(p); !ok || !near(back.X(), 1) || !near(back.Y(), 1) {
+			t.Errorf("%q: inverse maps %v to %v", s, p, back)
+		}
+	}
+	if _, ok := (Transform{Scale(0, 1)}).Matrix().Invert(); ok {
+		t.Error("flat scale inverted")
+	}
+}
+
+func TestTranslateTo(t *testing.T) {
+	rotated := Transform{Rotate(30, 5, 5)}
+	moved := rotated.TranslateTo(1, 2)
+	if got := moved.String(); got != "translate(1 2) rotate(30 5 5)" {
+		t.Errorf("added %q", got)
+	}
+	if got := moved.TranslateTo(3, 4).String(); got != "translate(3 4) rotate(30 5 5)" {
+		t.Errorf("replaced %q", got)
+	}
+	if rotated.String() != "rotate(30 5 5)" {
+		t.Errorf("translating changed the transform to %q", rotated)
+	}
+}
+
+func TestGroupMoveTo(t *testing.T) {
+	r := &Rect{X: 1, Y: 2, Width: 3, Height: 4, Fillable: NewFillable("", 1)}
+	g := &Group{Content: []SvgElement{r}, Fillable: NewFillable("", 1)}
+	g.MoveTo(10, 20)
+	g.MoveTo(5, 6)
+	// the content stays where it is, one translate moves all of it
+	if r.X != 1 || r.Y != 2 || g.Transform.String() != "translate(5 6)" {
+		t.Errorf("moved to %s", g)
+	}
+}
@@ -0,0 +1,77 @@
+package svg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Ellipsis ends a line cut short by Ellipsize
+const Ellipsis = "…"
+
+// WrapText breaks s into lines no wider than width in the font f.
+// Lines break at newlines and between words, a word wider than a line is broken between characters.
+func WrapText(s string, f Font, width float64) []string {
+	lines := []string{}
+	for _, paragraph := range strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n") {
+		lines = append(lines, wrapParagraph(paragraph, f, width)...)
+	}
+	return lines
+}
+
+// wrapParagraph wraps a text without newlines, it is at least one line
+func wrapParagraph(s string, f Font, width float64) []string {
+	lines := []string{}
+	line := ""
+	for _, word := range strings.FieldsFunc(s, unicode.IsSpace) {
+		next := word
+		if line != "" {
+			next = line + " " + word
+		}
+		if MeasureText(next, f).Width <= width {
+			line = next
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		// break the word until the rest fits on a line, or is a single rune wider than a line
+		line = word
+		for MeasureText(line, f).Width > width {
+			n := fitRunes(line, f, width)
+			if n == len(line) {
+				break
+			}
+			lines = append(lines, line[:n])
+			line = line[n:]
+		}
+	}
+	return append(lines, line)
+}
+
+// fitRunes is the length of the longest prefix of s fitting in width, at least one rune
+func fitRunes(s string, f Font, width float64) int {
+	n := 0
+	for i, r := range s {
+		end := i + len(string(r))
+		if n > 0 && MeasureText(s[:end], f).Width > width {
+			break
+		}
+		n = end
+	}
+	return n
+}
+
+// Ellipsize cuts s so that it fits in width with an Ellipsis at its end, s is returned as is when it fits
+func Ellipsize(s string, f Font, width float64) string {
+	if MeasureText(s, f).Width <= width {
+		return s
+	}
+	runes := []rune(s)
+	for n := len(runes); n > 0; n-- {
+		cut := strings.TrimRightFunc(string(runes[:n]), unicode.IsSpace) + Ellipsis
+		if MeasureText(cut, f).Width <= width {
+			return cut
+		}
+	}
+	return Ellipsis
+}
@@ -0,0 +1,66 @@
+package svg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	SetTextMeasurer(runeMeasurer{})
+	defer SetTextMeasurer(nil)
+	// in a font of size 1 a width counts runes
+	f := Font{Size: 1}
+	cases := []struct {
+		s     string
+		width float64
+		want  []string
+	}{
+		{"", 5, []string{""}},
+		{"abc def", 7, []string{"abc def"}},
+		{"abc def", 5, []string{"abc", "def"}},
+		{"abc  def\tghi", 7, []string{"abc def", "ghi"}},
+		{"a\n\nb", 5, []string{"a", "", "b"}},
+		{"a\r\nb", 5, []string{"a", "b"}},
+		{"abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"ab cdefgh", 3, []string{"ab", "cde", "fgh"}},
+		{"x abcdef y", 4, []string{"x", "abcd", "ef y"}},
+		{"héllo wörld", 5, []string{"héllo", "wörld"}},
+		// narrower than a rune, a line still holds one
+		{"abc de", 0.5, []string{"a", "b", "c", "d", "e"}},
+		{"a", 0, []string{"a"}},
+	}
+	for _, c := range cases {
+		if got := WrapText(c.s, f, c.width); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%q in %v: got %q, want %q", c.s, c.width, got, c.want)
+		}
+	}
+}
+
+func TestEllipsize(t *testing.T) {
+	SetTextMeasurer(runeMeasurer{})
+	defer SetTextMeasurer(nil)
+	f := Font{Size: 1}
+
+	if got := Ellipsize("abc", f, 3); got != "abc" {
+		t.Errorf("a fitting text is cut to %q", got)
+	}
+	if got := Ellipsize("abcdef", f, 4); got != "abc"+Ellipsis {
+		t.Errorf("cut to %q", got)
+	}
+	// the space before the ellipsis goes, a rune is never split
+	if got := Ellipsize("ab cd", f, 4); got != "ab"+Ellipsis {
+		t.Errorf("cut after a space to %q", got)
+	}
+	if got := Ellipsize("héllo", f, 3); got != "hé"+Ellipsis {
+		t.Errorf("cut after a wide rune to %q", got)
+	}
+	if got := Ellipsize("abc", f, 0); got != Ellipsis {
+		t.Errorf("cut to nothing as %q", got)
+	}
+	for w := 1.0; w < 10; w++ {
+		if got := Ellipsize(strings.Repeat("x", 20), f, w); MeasureText(got, f).Width > w {
+			t.Errorf("cut to %q, wider than %v", got, w)
+		}
+	}
+}
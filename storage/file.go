@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const fileExt = ".json"
+
+// FileStore keeps every mockup as a JSON file in a directory
+type FileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileStore creates the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+fileExt)
+}
+
+func (s *FileStore) List() ([]Mockup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	list := []Mockup{}
+	for _, info := range infos {
+		id := strings.TrimSuffix(info.Name(), fileExt)
+		if info.IsDir() || id == info.Name() || !ValidID(id) {
+			continue
+		}
+		m, err := s.read(id)
+		if err != nil {
+			return nil, err
+		}
+		m.Data = nil
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (s *FileStore) Get(id string) (Mockup, error) {
+	if !ValidID(id) {
+		return Mockup{}, ErrInvalidID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read(id)
+}
+
+func (s *FileStore) Put(id string, data []byte, c Condition) (Mockup, bool, error) {
+	if !ValidID(id) {
+		return Mockup{}, false, ErrInvalidID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, err := s.current(id)
+	if err != nil {
+		return Mockup{}, false, err
+	}
+	if err := c.check(current); err != nil {
+		return Mockup{}, false, err
+	}
+
+	// write to a temporary file first so readers never see a partial mockup
+	tmp, err := ioutil.TempFile(s.dir, id+".*.tmp")
+	if err != nil {
+		return Mockup{}, false, err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return Mockup{}, false, err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return Mockup{}, false, err
+	}
+	if err := os.Rename(tmp.Name(), s.path(id)); err != nil {
+		os.Remove(tmp.Name())
+		return Mockup{}, false, err
+	}
+	m, err := s.read(id)
+	return m, current == nil, err
+}
+
+func (s *FileStore) Delete(id string, c Condition) error {
+	if !ValidID(id) {
+		return ErrInvalidID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, err := s.current(id)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return ErrNotFound
+	}
+	if err := c.check(current); err != nil {
+		return err
+	}
+	return os.Remove(s.path(id))
+}
+
+func (s *FileStore) read(id string) (Mockup, error) {
+	data, err := ioutil.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return Mockup{}, ErrNotFound
+	}
+	if err != nil {
+		return Mockup{}, err
+	}
+	info, err := os.Stat(s.path(id))
+	if err != nil {
+		return Mockup{}, err
+	}
+	return Mockup{
+		ID:       id,
+		ETag:     ETag(data),
+		Modified: info.ModTime().UTC(),
+		Data:     data,
+	}, nil
+}
+
+func (s *FileStore) current(id string) (*Mockup, error) {
+	m, err := s.read(id)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
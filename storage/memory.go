@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps mockups in memory, it is meant for tests
+type MemoryStore struct {
+	mu      sync.Mutex
+	mockups map[string]Mockup
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		mockups: map[string]Mockup{},
+	}
+}
+
+func (s *MemoryStore) List() ([]Mockup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]Mockup, 0, len(s.mockups))
+	for _, m := range s.mockups {
+		m.Data = nil
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (s *MemoryStore) Get(id string) (Mockup, error) {
+	if !ValidID(id) {
+		return Mockup{}, ErrInvalidID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.mockups[id]
+	if !ok {
+		return Mockup{}, ErrNotFound
+	}
+	m.Data = append([]byte{}, m.Data...)
+	return m, nil
+}
+
+func (s *MemoryStore) Put(id string, data []byte, c Condition) (Mockup, bool, error) {
+	if !ValidID(id) {
+		return Mockup{}, false, ErrInvalidID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.current(id)
+	if err := c.check(current); err != nil {
+		return Mockup{}, false, err
+	}
+	m := Mockup{
+		ID:       id,
+		ETag:     ETag(data),
+		Modified: time.Now().UTC(),
+		Data:     append([]byte{}, data...),
+	}
+	s.mockups[id] = m
+	m.Data = data
+	return m, current == nil, nil
+}
+
+func (s *MemoryStore) Delete(id string, c Condition) error {
+	if !ValidID(id) {
+		return ErrInvalidID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.current(id)
+	if current == nil {
+		return ErrNotFound
+	}
+	if err := c.check(current); err != nil {
+		return err
+	}
+	delete(s.mockups, id)
+	return nil
+}
+
+func (s *MemoryStore) current(id string) *Mockup {
+	if m, ok := s.mockups[id]; ok {
+		return &m
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound           = errors.New("storage: mockup not found")
+	ErrPreconditionFailed = errors.New("storage: precondition failed")
+	ErrInvalidID          = errors.New("storage: invalid mockup id")
+)
+
+// Mockup is a saved mockup document
+type Mockup struct {
+	ID       string    `json:"id"`
+	ETag     string    `json:"etag"`
+	Modified time.Time `json:"modified"`
+	Data     []byte    `json:"-"`
+}
+
+// Condition restricts a write to a given state of the stored mockup, the zero value always matches
+type Condition struct {
+	// IfMatch requires the stored mockup to have one of the ETags of the list, "*" matches any stored mockup
+	IfMatch string
+	// IfNoneMatch requires the stored mockup to have none of the ETags of the list, "*" requires that no mockup is stored under the id
+	IfNoneMatch string
+}
+
+func (c Condition) check(current *Mockup) error {
+	if c.IfMatch != "" && (current == nil || !MatchETag(c.IfMatch, current.ETag, false)) {
+		return ErrPreconditionFailed
+	}
+	if c.IfNoneMatch != "" && current != nil && MatchETag(c.IfNoneMatch, current.ETag, true) {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
+// MatchETag reports whether the list of an If-Match or If-None-Match header holds etag, "*" holds any.
+// Weak tags, written W/"...", only match with weak comparison, which If-None-Match uses and If-Match doesn't.
+func MatchETag(list, etag string, weak bool) bool {
+	for _, t := range strings.Split(list, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" {
+			return true
+		}
+		if strings.HasPrefix(t, "W/") {
+			if !weak {
+				continue
+			}
+			t = t[len("W/"):]
+		}
+		if t == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Store persists mockup documents by id.
+// Put and Delete check the condition and write atomically.
+type Store interface {
+	// List returns the stored mockups without their data
+	List() ([]Mockup, error)
+	Get(id string) (Mockup, error)
+	// Put stores the data under the id, created reports that no mockup was stored there before
+	Put(id string, data []byte, c Condition) (m Mockup, created bool, err error)
+	Delete(id string, c Condition) error
+}
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+func ValidID(id string) bool {
+	return validID.MatchString(id)
+}
+
+// ETag returns the strong entity tag of the data
+func ETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
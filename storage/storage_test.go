@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"testing"
+)
+
+var (
+	stored   = []byte(`{"version":1,"elements":[]}`)
+	replaced = []byte(`{"version":1,"elements":[{}]}`)
+	tag      = ETag(stored)
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, func() Store { return NewMemoryStore() })
+}
+
+func TestFileStore(t *testing.T) {
+	testStore(t, func() Store {
+		s, err := NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
+
+// testStore runs the conditional writes of the Store interface against fresh stores
+func testStore(t *testing.T, newStore func() Store) {
+	// withStored is a store holding the stored data as "m"
+	withStored := func() Store {
+		s := newStore()
+		if _, _, err := s.Put("m", stored, Condition{}); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	puts := []struct {
+		name    string
+		s       Store
+		c       Condition
+		err     error
+		created bool
+	}{
+		{"create", newStore(), Condition{}, nil, true},
+		{"replace", withStored(), Condition{}, nil, false},
+		{"create if none", newStore(), Condition{IfNoneMatch: "*"}, nil, true},
+		{"replace if none", withStored(), Condition{IfNoneMatch: "*"}, ErrPreconditionFailed, false},
+		{"replace if match", withStored(), Condition{IfMatch: tag}, nil, false},
+		{"replace if match in list", withStored(), Condition{IfMatch: `"other", ` + tag}, nil, false},
+		{"replace if other", withStored(), Condition{IfMatch: `"other"`}, ErrPreconditionFailed, false},
+		// If-Match compares strongly, If-None-Match weakly
+		{"replace if weak match", withStored(), Condition{IfMatch: "W/" + tag}, ErrPreconditionFailed, false},
+		{"replace if not weak match", withStored(), Condition{IfNoneMatch: "W/" + tag}, ErrPreconditionFailed, false},
+		{"replace if not other", withStored(), Condition{IfNoneMatch: `"other"`}, nil, false},
+		{"replace if any", withStored(), Condition{IfMatch: "*"}, nil, false},
+		{"create if any", newStore(), Condition{IfMatch: "*"}, ErrPreconditionFailed, false},
+	}
+	for _, c := range puts {
+		before, _ := c.s.Get("m")
+		m, created, err := c.s.Put("m", replaced, c.c)
+		if err != c.err {
+			t.Errorf("%s: error %v, want %v", c.name, err, c.err)
+			continue
+		}
+		if err == nil && (created != c.created || m.ETag != ETag(replaced)) {
+			t.Errorf("%s: created %v with %s, want %v with %s", c.name, created, m.ETag, c.created, ETag(replaced))
+		}
+		// a failed precondition leaves the store as it was
+		want := replaced
+		if err != nil {
+			want = before.Data
+		}
+		if got, _ := c.s.Get("m"); string(got.Data) != string(want) {
+			t.Errorf("%s: stored %s, want %s", c.name, got.Data, want)
+		}
+	}
+
+	deletes := []struct {
+		name string
+		s    Store
+		c    Condition
+		err  error
+	}{
+		{"delete", withStored(), Condition{}, nil},
+		{"delete missing", newStore(), Condition{}, ErrNotFound},
+		{"delete if match", withStored(), Condition{IfMatch: tag}, nil},
+		{"delete if other", withStored(), Condition{IfMatch: `"other"`}, ErrPreconditionFailed},
+	}
+	for _, c := range deletes {
+		if err := c.s.Delete("m", c.c); err != c.err {
+			t.Errorf("%s: error %v, want %v", c.name, err, c.err)
+		}
+		if _, err := c.s.Get("m"); c.err == nil && err != ErrNotFound {
+			t.Errorf("%s: still stored, %v", c.name, err)
+		}
+	}
+
+	s := newStore()
+	for _, id := range []string{"../x", "a b", ""} {
+		if _, _, err := s.Put(id, stored, Condition{}); err != ErrInvalidID {
+			t.Errorf("put %q: error %v, want %v", id, err, ErrInvalidID)
+		}
+		if err := s.Delete(id, Condition{}); err != ErrInvalidID {
+			t.Errorf("delete %q: error %v, want %v", id, err, ErrInvalidID)
+		}
+	}
+
+	for _, id := range []string{"b", "a", "c"} {
+		if _, _, err := s.Put(id, stored, Condition{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Delete("c", Condition{})
+	list, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// listed by id, without the data
+	if len(list) != 2 || list[0].ID != "a" || list[1].ID != "b" || list[0].ETag != tag || list[0].Data != nil {
+		t.Errorf("listed %v", list)
+	}
+}